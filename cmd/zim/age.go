@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/imageage"
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+)
+
+// runAge 실행 중인 이미지의 생성 시각과 나이를 출력하고 최대 나이를 넘으면 종료 코드 1로 종료
+func runAge(args []string) {
+	fs := flag.NewFlagSet("age", flag.ExitOnError)
+	kubeconfig := fs.String("kubeconfig", defaultKubeconfig(),
+		"Absolute path to the kubeconfig file")
+	namespace := fs.String("namespace", "",
+		"Only check pods in this namespace (default: all namespaces)")
+	maxAgeFlag := fs.String("max-age", "",
+		"Exit with status 1 if any running image is older than this, e.g. 90d, 12w or 720h")
+	platformFlag := fs.String("platform", "linux/amd64",
+		"Platform used for multi-arch images when the node platform is unknown")
+	registryOptions := addRegistryFlags(fs)
+	fs.Parse(args)
+
+	maxAge, err := parseAge(*maxAgeFlag)
+	if err != nil {
+		log.Fatalf("Invalid max age: %v", err)
+	}
+	platform, err := parsePlatform(*platformFlag)
+	if err != nil {
+		log.Fatalf("Invalid platform: %v", err)
+	}
+	client, err := registryOptions.client()
+	if err != nil {
+		log.Fatalf("Failed to create registry client: %v", err)
+	}
+
+	kubeClient, err := kubernetes.NewKubeClient(*kubeconfig)
+	if err != nil {
+		log.Fatalf("Failed to create kubernetes client: %v", err)
+	}
+	containers, err := kubernetes.GetRunningContainers(kubeClient.GetClientset(), *namespace)
+	if err != nil {
+		log.Fatalf("Failed to get running containers: %v", err)
+	}
+	nodes, err := kubernetes.GetNodePlatforms(kubeClient.GetClientset())
+	if err != nil {
+		log.Printf("Warning: Failed to get node platforms, using %s: %v", platform, err)
+	}
+
+	now := time.Now()
+	ages := imageage.NewChecker(client, platform).Check(context.Background(), containers, nodes)
+	imageage.PrintImageAges(ages, maxAge, now)
+
+	if stale := imageage.CountStale(ages, maxAge, now); stale > 0 {
+		fmt.Fprintf(os.Stderr, "%d running images are older than %s\n", stale, *maxAgeFlag)
+		os.Exit(1)
+	}
+}
+
+// parseAge "90d", "12w" 또는 time.ParseDuration 형식의 기간을 파싱 (빈 문자열은 0)
+func parseAge(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if number, ok := strings.CutSuffix(value, suffix); ok {
+			n, err := strconv.Atoi(number)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", value)
+			}
+			return time.Duration(n) * unit, nil
+		}
+	}
+	return time.ParseDuration(value)
+}
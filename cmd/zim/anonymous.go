@@ -0,0 +1,40 @@
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+)
+
+// runAnonymousPulls docker.io 이미지를 익명으로 가져오는 워크로드 목록 출력
+func runAnonymousPulls(args []string) {
+	fs := flag.NewFlagSet("anonymous-pulls", flag.ExitOnError)
+	kubeconfig := fs.String("kubeconfig", defaultKubeconfig(),
+		"Absolute path to the kubeconfig file")
+	since := fs.Int("since", 24,
+		"Count pull events from the last N hours (default: 24)")
+	historyOptions := addHistoryFlags(fs)
+	fs.Parse(args)
+
+	kubeClient, err := kubernetes.NewKubeClient(*kubeconfig)
+	if err != nil {
+		log.Fatalf("Failed to create kubernetes client: %v", err)
+	}
+
+	store, err := historyOptions.open()
+	if err != nil {
+		log.Fatalf("Failed to open history store: %v", err)
+	}
+	pullEvents, err := getPullEvents(store, *since, "")
+	if err != nil {
+		log.Fatalf("Failed to get pull events: %v", err)
+	}
+
+	pulls, err := kubernetes.GetAnonymousDockerHubPulls(kubeClient.GetClientset(), pullEvents)
+	if err != nil {
+		log.Fatalf("Failed to find anonymous Docker Hub pulls: %v", err)
+	}
+
+	kubernetes.PrintAnonymousDockerHubPulls(pulls, *since)
+}
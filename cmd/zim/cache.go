@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/docker"
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/pullcache"
+)
+
+// runCacheReport 풀 횟수, 이미지 크기, Docker Hub 남은 quota로 pull-through 캐시가 필요한 레지스트리와 이미지를 추천
+func runCacheReport(args []string) {
+	fs := flag.NewFlagSet("cache-report", flag.ExitOnError)
+	kubeconfig := fs.String("kubeconfig", defaultKubeconfig(),
+		"Absolute path to the kubeconfig file")
+	since := fs.Int("since", 24,
+		"Count pull events from the last N hours (default: 24)")
+	top := fs.Int("top", 20,
+		"Number of images to list")
+	imageSizes := fs.Bool("image-sizes", true,
+		"Estimate data saved from node image sizes and kubelet \"Image size\" events")
+	egressCost := fs.String("egress-cost", "",
+		"Egress cost in $/GB per registry, e.g. docker.io=0.09,default=0.05")
+	dockerOptions := addDockerHubFlags(fs)
+	httpOptions := addHTTPFlags(fs)
+	historyOptions := addHistoryFlags(fs)
+	fs.Parse(args)
+
+	costPerGB, err := parseCostPerGB(*egressCost)
+	if err != nil {
+		log.Fatalf("Invalid egress cost: %v", err)
+	}
+	httpClient, err := httpOptions.client()
+	if err != nil {
+		log.Fatalf("Failed to create HTTP client: %v", err)
+	}
+
+	kubeClient, err := kubernetes.NewKubeClient(*kubeconfig)
+	if err != nil {
+		log.Fatalf("Failed to create kubernetes client: %v", err)
+	}
+	store, err := historyOptions.open()
+	if err != nil {
+		log.Fatalf("Failed to open history store: %v", err)
+	}
+	pullEvents, err := getPullEvents(store, *since, "")
+	if err != nil {
+		log.Fatalf("Failed to get pull events: %v", err)
+	}
+
+	opts := pullcache.Options{Since: *since, CostPerGB: costPerGB}
+	if *imageSizes {
+		opts.Sizes, err = kubernetes.GetImageSizes(kubeClient.GetClientset(), time.Now().Add(-time.Duration(*since)*time.Hour))
+		if err != nil {
+			log.Printf("Warning: Failed to get image sizes: %v", err)
+		}
+	}
+
+	// Docker Hub 남은 풀 quota
+	quotas, err := docker.NewRateLimitProvider(dockerOptions.client(httpClient), dockerOptions.auth()).Check(context.Background())
+	if err != nil {
+		log.Printf("Warning: Failed to get Docker Hub rate limit: %v", err)
+	}
+	opts.Quotas = quotas
+
+	registries, images := pullcache.Recommend(pullEvents, opts)
+	pullcache.PrintRecommendations(registries, images, *since, *top)
+}
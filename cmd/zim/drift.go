@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/drift"
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+)
+
+// runDrift 실행 중인 이미지 digest를 레지스트리 태그가 현재 가리키는 digest와 비교하여 출력
+func runDrift(args []string) {
+	fs := flag.NewFlagSet("drift", flag.ExitOnError)
+	kubeconfig := fs.String("kubeconfig", defaultKubeconfig(),
+		"Absolute path to the kubeconfig file")
+	namespace := fs.String("namespace", "",
+		"Only check pods in this namespace (default: all namespaces)")
+	platformFlag := fs.String("platform", "linux/amd64",
+		"Platform used to read the creation time of multi-arch images")
+	all := fs.Bool("all", false,
+		"Also list up-to-date, pinned and unknown containers")
+	registryOptions := addRegistryFlags(fs)
+	fs.Parse(args)
+
+	platform, err := parsePlatform(*platformFlag)
+	if err != nil {
+		log.Fatalf("Invalid platform: %v", err)
+	}
+	client, err := registryOptions.client()
+	if err != nil {
+		log.Fatalf("Failed to create registry client: %v", err)
+	}
+
+	kubeClient, err := kubernetes.NewKubeClient(*kubeconfig)
+	if err != nil {
+		log.Fatalf("Failed to create kubernetes client: %v", err)
+	}
+	containers, err := kubernetes.GetRunningContainers(kubeClient.GetClientset(), *namespace)
+	if err != nil {
+		log.Fatalf("Failed to get running containers: %v", err)
+	}
+
+	drifts := drift.NewChecker(client, platform).Check(context.Background(), containers)
+	drift.PrintImageDrift(drifts, *all)
+}
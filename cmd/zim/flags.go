@@ -0,0 +1,85 @@
+package main
+
+import (
+	"flag"
+	"net/http"
+	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/docker"
+	"github.com/suslmk-lee/zim-image-management/pkg/httpclient"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// httpFlags 레지스트리 및 API 호출에 사용할 HTTP 클라이언트 플래그
+type httpFlags struct {
+	timeout   *time.Duration
+	userAgent *string
+	proxy     *string
+	caFile    *string
+	insecure  *bool
+}
+
+// addHTTPFlags FlagSet에 HTTP 클라이언트 관련 플래그를 등록
+func addHTTPFlags(fs *flag.FlagSet) *httpFlags {
+	return &httpFlags{
+		timeout: fs.Duration("http-timeout", httpclient.DefaultTimeout,
+			"Timeout for registry and API requests"),
+		userAgent: fs.String("user-agent", httpclient.DefaultUserAgent,
+			"User-Agent header sent to registries and APIs"),
+		proxy: fs.String("proxy", "",
+			"HTTP(S) proxy URL (default: HTTP_PROXY/HTTPS_PROXY environment variables)"),
+		caFile: fs.String("ca-file", "",
+			"PEM file with additional CA certificates for registries and APIs"),
+		insecure: fs.Bool("insecure-skip-tls-verify", false,
+			"Skip TLS certificate verification (testing only)"),
+	}
+}
+
+// client 플래그 값으로 HTTP 클라이언트를 생성
+func (f *httpFlags) client() (*http.Client, error) {
+	return httpclient.New(httpclient.Config{
+		Timeout:            *f.timeout,
+		UserAgent:          *f.userAgent,
+		ProxyURL:           *f.proxy,
+		CAFile:             *f.caFile,
+		InsecureSkipVerify: *f.insecure,
+	})
+}
+
+// registryFlags 레지스트리 클라이언트 생성에 필요한 HTTP 및 인증 플래그
+type registryFlags struct {
+	http              *httpFlags
+	dockerConfig      *string
+	dockerRegistryURL *string
+}
+
+// addRegistryFlags FlagSet에 레지스트리 클라이언트 관련 플래그를 등록
+func addRegistryFlags(fs *flag.FlagSet) *registryFlags {
+	return &registryFlags{
+		http: addHTTPFlags(fs),
+		dockerConfig: fs.String("docker-config", registry.DefaultDockerConfigPath(),
+			"Path to a docker config.json with registry credentials"),
+		dockerRegistryURL: fs.String("docker-registry-url", docker.DefaultRegistryURL,
+			"Docker Hub registry endpoint used for docker.io manifests and blobs"),
+	}
+}
+
+// client 플래그 값으로 인증이 적용된 레지스트리 클라이언트를 생성
+func (f *registryFlags) client() (*registry.Client, error) {
+	httpClient, err := f.http.client()
+	if err != nil {
+		return nil, err
+	}
+	credentials, err := registry.DockerConfigCredentials(*f.dockerConfig)
+	if err != nil {
+		return nil, err
+	}
+	client := registry.NewClient(httpClient, credentials)
+	client.Endpoints = registryEndpoints(*f.dockerRegistryURL)
+	return client, nil
+}
+
+// registryEndpoints --docker-registry-url을 레지스트리 클라이언트의 docker.io 엔드포인트로 변환
+func registryEndpoints(dockerRegistryURL string) map[string]string {
+	return map[string]string{registry.DockerHubRegistry: dockerRegistryURL}
+}
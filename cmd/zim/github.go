@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/github"
+)
+
+// resolveGitHubToken 플래그, 환경 변수(GITHUB_TOKEN, GH_TOKEN), GitHub App 순서로 토큰을 결정
+func resolveGitHubToken(client *github.Client, flagToken string, appID, installationID int64, privateKeyPath string) (string, string, error) {
+	if token, source := github.ResolveToken(flagToken); token != "" {
+		return token, source, nil
+	}
+	if appID == 0 && installationID == 0 && privateKeyPath == "" {
+		return "", "", nil
+	}
+
+	privateKey, err := os.ReadFile(privateKeyPath)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read GitHub App private key: %v", err)
+	}
+	installationToken, err := client.CreateInstallationToken(context.Background(), github.AppCredentials{
+		AppID:          appID,
+		InstallationID: installationID,
+		PrivateKey:     privateKey,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create GitHub App installation token: %v", err)
+	}
+	return installationToken.Token, "GitHub App installation", nil
+}
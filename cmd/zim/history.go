@@ -0,0 +1,131 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/history"
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/ratelimit"
+)
+
+// runHistory 이력 저장소의 세그먼트, 기간 내 풀 이벤트와 rate limit 추이를 출력하고 보관 기간이 지난 세그먼트를 정리
+func runHistory(args []string) {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	since := fs.Int("since", 24*30,
+		"Summarize stored pull events and rate limit samples from the last N hours (default: 720)")
+	record := fs.Bool("record", false,
+		"Record pull events from the local journal before summarizing")
+	historyOptions := addHistoryFlags(fs)
+	fs.Parse(args)
+
+	if *historyOptions.dir == "" {
+		log.Fatalf("--history-dir is required")
+	}
+	store, err := historyOptions.open()
+	if err != nil {
+		log.Fatalf("Failed to open history store: %v", err)
+	}
+	if *record {
+		if _, err := getPullEvents(store, *since, ""); err != nil && !kubernetes.IsNoPullEvents(err) {
+			log.Printf("Warning: Failed to get pull events: %v", err)
+		}
+	}
+
+	from := time.Now().Add(-time.Duration(*since) * time.Hour)
+	pulls, err := store.Pulls(from, time.Time{})
+	if err != nil {
+		log.Fatalf("Failed to read pull history: %v", err)
+	}
+	samples, err := store.RateLimits(from, time.Time{})
+	if err != nil {
+		log.Fatalf("Failed to read rate limit history: %v", err)
+	}
+	segments, err := store.Segments()
+	if err != nil {
+		log.Fatalf("Failed to list history segments: %v", err)
+	}
+	history.PrintHistory(store.Dir, segments, pulls, samples, *since)
+}
+
+// historyFlags 풀 이벤트와 rate limit 샘플 이력 저장소 플래그
+type historyFlags struct {
+	dir       *string
+	retention *string
+}
+
+// addHistoryFlags FlagSet에 이력 저장소 관련 플래그를 등록
+func addHistoryFlags(fs *flag.FlagSet) *historyFlags {
+	return &historyFlags{
+		dir: fs.String("history-dir", "",
+			"Directory of the pull event and rate limit history store (default: disabled)"),
+		retention: fs.String("history-retention", "90d",
+			"Delete history segments older than this, e.g. 90d, 52w or 0 to keep everything"),
+	}
+}
+
+// open 플래그 값으로 이력 저장소를 열고 보관 기간이 지난 세그먼트를 삭제 (--history-dir가 없으면 nil)
+func (f *historyFlags) open() (*history.Store, error) {
+	if *f.dir == "" {
+		return nil, nil
+	}
+	retention, err := parseAge(*f.retention)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --history-retention: %v", err)
+	}
+	store, err := history.Open(*f.dir, retention)
+	if err != nil {
+		return nil, err
+	}
+	store.Warnf = func(format string, args ...any) {
+		log.Printf("Warning: history: "+format, args...)
+	}
+	if _, err := store.Prune(time.Now()); err != nil {
+		log.Printf("Warning: Failed to prune history: %v", err)
+	}
+	return store, nil
+}
+
+// getPullEvents 최근 since 시간의 journal 풀 이벤트를 조회
+// 이력 저장소가 있으면 새 이벤트를 기록하고 journal에 남아 있지 않은 저장된 이벤트를 합쳐서 반환
+// (호스트 이름이 없는 라인은 node, 비어 있으면 이 호스트의 이름으로 기록)
+func getPullEvents(store *history.Store, since int, node string) ([]string, error) {
+	pullEvents, err := kubernetes.GetPullEvents(fmt.Sprintf("%dh ago", since))
+	if store == nil {
+		return pullEvents, err
+	}
+	if node == "" {
+		node, _ = os.Hostname()
+	}
+	if err == nil {
+		if _, err := store.AddPullEvents(pullEvents, node); err != nil {
+			log.Printf("Warning: Failed to record pull history: %v", err)
+		}
+	}
+
+	records, historyErr := store.Pulls(time.Now().Add(-time.Duration(since)*time.Hour), time.Time{})
+	if historyErr != nil {
+		log.Printf("Warning: Failed to read pull history: %v", historyErr)
+		return pullEvents, err
+	}
+	if len(records) == 0 {
+		return pullEvents, err
+	}
+	if err != nil && !kubernetes.IsNoPullEvents(err) {
+		log.Printf("Warning: Failed to get pull events, using stored history only: %v", err)
+	}
+	return history.MergePullEvents(pullEvents, records, node), nil
+}
+
+// recordRateLimits rate limit 조회 결과를 이력 저장소에 샘플로 기록 (저장소가 없으면 아무것도 하지 않음)
+func recordRateLimits(store *history.Store, results []ratelimit.Result) {
+	if store == nil || len(results) == 0 {
+		return
+	}
+	if err := store.AddRateLimits(time.Now(), results); err != nil {
+		log.Printf("Warning: Failed to record rate limit history: %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// runInspect 레지스트리에서 이미지의 매니페스트, 플랫폼별 크기, 태그 수를 조회하여 출력
+func runInspect(args []string) {
+	fs := flag.NewFlagSet("inspect", flag.ExitOnError)
+	registryOptions := addRegistryFlags(fs)
+	listTags := fs.Bool("tags", false,
+		"Also list the repository tags")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s inspect [options] <image>\n\nOptions:\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	ref, err := registry.ParseReference(fs.Arg(0))
+	if err != nil {
+		log.Fatalf("Invalid image reference: %v", err)
+	}
+
+	client, err := registryOptions.client()
+	if err != nil {
+		log.Fatalf("Failed to create registry client: %v", err)
+	}
+
+	ctx := context.Background()
+	manifest, err := client.GetManifest(ctx, ref)
+	if err != nil {
+		log.Fatalf("Failed to get manifest: %v", err)
+	}
+	sizes, err := client.ImageSizes(ctx, ref)
+	if err != nil {
+		log.Fatalf("Failed to compute image sizes: %v", err)
+	}
+
+	var tags []string
+	if *listTags {
+		tags, err = client.ListTags(ctx, ref)
+		if err != nil {
+			log.Fatalf("Failed to list tags: %v", err)
+		}
+	}
+
+	registry.PrintImageInfo(ref, manifest, sizes, tags)
+	for _, tag := range tags {
+		fmt.Println(tag)
+	}
+}
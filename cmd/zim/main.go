@@ -6,16 +6,53 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"time"
 
-	"github.com/suslmk-lee/zim-image-management/pkg/docker"
-	"github.com/suslmk-lee/zim-image-management/pkg/github"
 	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/output"
+	"github.com/suslmk-lee/zim-image-management/pkg/ratelimit"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+	"github.com/suslmk-lee/zim-image-management/pkg/report"
 )
 
 func printUsage() {
 	fmt.Printf(`ZIM (Zim Image Management) - Docker Image Usage Monitor
 
 Usage: %s [options]
+       %s <command> [options]
+
+Commands:
+  anonymous-pulls
+        List namespaces and workloads pulling docker.io images without credentials
+  inspect <image>
+        Show manifest, per-platform compressed sizes and tags of an image
+  drift
+        List workloads running an older digest than their tag points to in the registry
+  upgrades
+        List newer patch, minor and major versions of in-use images per workload
+  platforms
+        Check in-use images against node architectures and find pods on incompatible nodes
+  age
+        Show the creation time and age of running images, failing if older than --max-age
+  vulns --report <files>
+        Join Trivy/Grype reports and CycloneDX/SPDX SBOMs to running images, weighted by pods and pulls
+  signatures
+        Check cosign signatures and attestations (tags and OCI referrers) of running image digests
+  cache-report
+        Recommend registries and images for a pull-through cache with estimated upstream pulls saved
+  mirror --to <registry>
+        Copy in-use images (all platforms, by digest) into another registry, skipping existing blobs
+  mirror-config --to <registry> | --mirror <upstream=mirror>
+        Generate CRI-O registries.conf.d and containerd hosts.toml mirror files plus MachineConfig/DaemonSet bundles
+  mirror-audit
+        Compare this node's mirror configuration with its pull events and report pulls that bypassed the mirror
+  serve --metrics-addr <addr>
+        Collect pull events, in-use images and rate limits periodically and serve Prometheus metrics, a JSON API
+        and a web dashboard
+  textfile --dir <dir>
+        Write this node's pull statistics and rate limits atomically as an OpenMetrics .prom file for node_exporter
+  history --history-dir <dir>
+        Summarize stored pull events and rate limit samples and prune segments past the retention period
 
 Options:
   --kubeconfig string
@@ -23,13 +60,70 @@ Options:
   --since int
         Show statistics for the last N hours (default: 24)
   --github-token string
-        GitHub personal access token for checking GitHub Container Registry rate limits
+        GitHub token for GitHub API rate limits and authenticated ghcr.io checks
+        (default: GITHUB_TOKEN or GH_TOKEN environment variable)
+  --github-app-id int
+        GitHub App ID used to create an installation token
+  --github-app-installation-id int
+        GitHub App installation ID used to create an installation token
+  --github-app-private-key string
+        Path to the GitHub App private key (PEM)
+  --ghcr-username string
+        GitHub username used with --github-token for ghcr.io (default: zim)
+  --ghcr-images string
+        Comma-separated ghcr.io images to probe (default: ghcr.io images running in the cluster)
   --docker-username string
         Docker Hub username for authenticated rate limit checking
   --docker-password string
         Docker Hub password for authenticated rate limit checking
   --docker-token string
         Docker Hub token (alternative to username/password)
+  --docker-auth-url string
+        Docker Hub token endpoint, used instead of the registry's token realm when changed (default: https://auth.docker.io/token)
+  --docker-registry-url string
+        Docker Hub registry endpoint (default: https://registry-1.docker.io)
+  --github-api-url string
+        GitHub API endpoint (default: https://api.github.com)
+  --quay-url string
+        Quay registry endpoint (default: https://quay.io)
+  --quay-token string
+        Quay API OAuth token for repository pull statistics
+  --harbor-url string
+        Harbor endpoint for project storage quota checks (e.g. https://harbor.internal)
+  --harbor-username string
+        Harbor username (robot accounts supported)
+  --harbor-password string
+        Harbor password or robot account secret
+  --http-timeout duration
+        Timeout for registry and API requests (default: 30s)
+  --user-agent string
+        User-Agent header sent to registries and APIs (default: zim/1.0.0)
+  --proxy string
+        HTTP(S) proxy URL (default: HTTP_PROXY/HTTPS_PROXY environment variables)
+  --ca-file string
+        PEM file with additional CA certificates for registries and APIs
+  --insecure-skip-tls-verify
+        Skip TLS certificate verification (testing only)
+  --image-sizes
+        Estimate data pulled from node image sizes and kubelet "Image size" events (default: true)
+  --registry-sizes
+        Use compressed sizes from registry manifests for pulled images (more accurate, slower)
+  --size-platform string
+        Platform used for registry sizes of multi-arch images (default: linux/amd64)
+  --docker-config string
+        Path to a docker config.json with registry credentials (default: $HOME/.docker/config.json)
+  --egress-cost string
+        Egress cost in $/GB per registry, e.g. docker.io=0.09,default=0.05
+  --output string
+        Output format: table, json, yaml, csv, markdown (default: table)
+  --table string
+        Table to write for csv or markdown output: images, rate-limits
+        (default: images for csv, both for markdown)
+  --history-dir string
+        Record pull events and rate limit samples in this directory and include stored pulls older
+        than the node journal in statistics (default: disabled)
+  --history-retention string
+        Delete history segments older than this, e.g. 90d, 52w or 0 to keep everything (default: 90d)
   --version
         Show version information
 
@@ -40,31 +134,150 @@ Examples:
   # Show image pull statistics for the last 48 hours
   %s --since 48
 
+  # Estimate data pulled and egress cost using registry manifest sizes
+  %s --registry-sizes --egress-cost docker.io=0.09,default=0.05
+
+  # Save pull statistics and rate limits as JSON (schema zim/v1)
+  %s --output json > zim.json
+
+  # Save rate limits as CSV (one table per file)
+  %s --output csv --table rate-limits > ratelimits.csv
+
   # Check Docker Hub rate limits with authentication
   %s --docker-username user --docker-password pass
 
-  # Check GitHub Container Registry rate limits
-  %s --github-token ghp_xxxxxxxxxxxx
-`, os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0])
+  # Check GitHub API rate limits and probe ghcr.io with a token
+  %s --github-token ghp_xxxxxxxxxxxx --ghcr-images ghcr.io/org/app:latest
+
+  # List workloads pulling from Docker Hub anonymously
+  %s anonymous-pulls --since 48
+
+  # Find workloads whose tag was re-pushed since their pods started
+  %s drift --namespace production
+
+  # List newer versions, using only plain x.y.z tags for nginx
+  %s upgrades --exclude-registries registry.k8s.io --tag-pattern 'nginx=^[0-9.]+$'
+
+  # Find images without a manifest for the arm64 node pool
+  %s platforms
+
+  # Fail a CI job if any running image was built more than 90 days ago
+  %s age --max-age 90d
+
+  # Rank vulnerable running images from scanner reports
+  %s vulns --report 'reports/*.json'
+
+  # Verify cosign signatures of running images and fail on unsigned ones
+  %s signatures --key cosign.pub --require-signed
+
+  # Recommend what to put behind a pull-through cache
+  %s cache-report --since 72 --docker-token <token>
+
+  # Preview copying all running images into an internal registry
+  %s mirror --to registry.internal --dry-run
+
+  # Generate node mirror configuration for the registries seen in pull events
+  %s mirror-config --to registry.internal --output-dir ./mirror-config
+
+  # Check on a node whether pulls went straight upstream instead of through the mirror
+  %s mirror-audit --to registry.internal --fail-on-bypass
+
+  # Expose Prometheus metrics on :9090, collecting every 5 minutes
+  %s serve --metrics-addr :9090 --interval 5m
+
+  # Write node pull statistics for the node_exporter textfile collector (e.g. from a systemd timer)
+  %s textfile --dir /var/lib/node_exporter/textfile_collector
+
+  # Show pull statistics for the last 90 days using stored history beyond journal retention
+  %s --since 2160 --history-dir /var/lib/zim/history
+
+  # Summarize rate limit trends recorded in the history store over the last 30 days
+  %s history --history-dir /var/lib/zim/history --since 720
+`, os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0])
+}
+
+// defaultKubeconfig 기본 kubeconfig 파일 경로를 반환
+func defaultKubeconfig() string {
+	return filepath.Join(os.Getenv("HOME"), ".kube", "config")
 }
 
 func main() {
+	// 서브커맨드 실행
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "anonymous-pulls":
+			runAnonymousPulls(os.Args[2:])
+			return
+		case "inspect":
+			runInspect(os.Args[2:])
+			return
+		case "drift":
+			runDrift(os.Args[2:])
+			return
+		case "upgrades":
+			runUpgrades(os.Args[2:])
+			return
+		case "platforms":
+			runPlatforms(os.Args[2:])
+			return
+		case "age":
+			runAge(os.Args[2:])
+			return
+		case "vulns":
+			runVulns(os.Args[2:])
+			return
+		case "signatures":
+			runSignatures(os.Args[2:])
+			return
+		case "cache-report":
+			runCacheReport(os.Args[2:])
+			return
+		case "mirror":
+			runMirror(os.Args[2:])
+			return
+		case "mirror-config":
+			runMirrorConfig(os.Args[2:])
+			return
+		case "mirror-audit":
+			runMirrorAudit(os.Args[2:])
+			return
+		case "serve":
+			runServe(os.Args[2:])
+			return
+		case "textfile":
+			runTextfile(os.Args[2:])
+			return
+		case "history":
+			runHistory(os.Args[2:])
+			return
+		}
+	}
+
 	// 커스텀 usage 메시지 설정
 	flag.Usage = printUsage
 
 	// 플래그 설정
-	kubeconfig := flag.String("kubeconfig", filepath.Join(os.Getenv("HOME"), ".kube", "config"),
+	kubeconfig := flag.String("kubeconfig", defaultKubeconfig(),
 		"Absolute path to the kubeconfig file")
 	since := flag.Int("since", 24,
 		"Show statistics for the last N hours (default: 24)")
-	githubToken := flag.String("github-token", "",
-		"GitHub personal access token for checking GitHub Container Registry rate limits")
-	dockerUsername := flag.String("docker-username", "",
-		"Docker Hub username for authenticated rate limit checking")
-	dockerPassword := flag.String("docker-password", "",
-		"Docker Hub password for authenticated rate limit checking")
-	dockerToken := flag.String("docker-token", "",
-		"Docker Hub token (alternative to username/password)")
+	limitFlags := addRateLimitFlags(flag.CommandLine)
+	imageSizes := flag.Bool("image-sizes", true,
+		"Estimate data pulled from node image sizes and kubelet \"Image size\" events")
+	registrySizes := flag.Bool("registry-sizes", false,
+		"Use compressed sizes from registry manifests for pulled images")
+	sizePlatform := flag.String("size-platform", "linux/amd64",
+		"Platform used for registry sizes of multi-arch images")
+	dockerConfig := flag.String("docker-config", registry.DefaultDockerConfigPath(),
+		"Path to a docker config.json with registry credentials")
+	egressCost := flag.String("egress-cost", "",
+		"Egress cost in $/GB per registry, e.g. docker.io=0.09,default=0.05")
+	outputFormat := flag.String("output", string(output.FormatTable),
+		"Output format: "+output.Formats)
+	outputTable := flag.String("table", "",
+		"Table to write for csv or markdown output: "+report.Tables+" (default: images for csv, both for markdown)")
+	httpOptions := addHTTPFlags(flag.CommandLine)
+	historyOptions := addHistoryFlags(flag.CommandLine)
 
 	// 버전 플래그 추가
 	version := flag.Bool("version", false,
@@ -78,45 +291,46 @@ func main() {
 		os.Exit(0)
 	}
 
-	// Kubernetes 클라이언트 생성
-	kubeClient, err := kubernetes.NewKubeClient(*kubeconfig)
+	format, err := output.ParseFormat(*outputFormat)
 	if err != nil {
-		log.Fatalf("Failed to create kubernetes client: %v", err)
+		log.Fatalf("Invalid --output: %v", err)
 	}
-
-	// GitHub Container Registry rate limit 확인
-	if *githubToken != "" {
-		githubLimit, err := github.GetDockerRateLimit(*githubToken)
-		if err != nil {
-			log.Printf("Warning: Failed to get GitHub Container Registry rate limit: %v", err)
-		} else {
-			github.PrintGitHubRateLimit(githubLimit)
-		}
+	table, err := report.ParseTable(*outputTable)
+	if err != nil {
+		log.Fatalf("Invalid --table: %v", err)
 	}
 
-	// Docker Hub rate limit 확인 (인증된 사용자 또는 익명)
-	auth := docker.DockerHubAuth{
-		Username: *dockerUsername,
-		Password: *dockerPassword,
-		Token:    *dockerToken,
+	// 레지스트리 및 API 호출용 HTTP 클라이언트 생성
+	httpClient, err := httpOptions.client()
+	if err != nil {
+		log.Fatalf("Failed to create HTTP client: %v", err)
 	}
 
-	dockerLimit, err := docker.GetDockerHubRateLimit(auth)
+	// 풀 이벤트와 rate limit 이력 저장소 (--history-dir가 없으면 nil)
+	store, err := historyOptions.open()
 	if err != nil {
-		log.Printf("Warning: Failed to get Docker Hub rate limit: %v\n", err)
+		log.Fatalf("Failed to open history store: %v", err)
 	}
 
+	// Kubernetes 클라이언트 생성
+	kubeClient, err := kubernetes.NewKubeClient(*kubeconfig)
 	if err != nil {
-		log.Printf("Warning: Failed to get Docker Hub rate limit: %v", err)
-	} else {
-		docker.PrintDockerHubRateLimit(dockerLimit, auth)
+		log.Fatalf("Failed to create kubernetes client: %v", err)
 	}
 
-	// 시간 범위 계산
-	sinceTime := fmt.Sprintf("%dh ago", *since)
+	// 클러스터가 사용하는 모든 레지스트리의 rate limit 확인
+	clusterImages, err := kubernetes.GetPodImages(kubeClient.GetClientset())
+	if err != nil {
+		log.Printf("Warning: Failed to get cluster images for rate limit checks: %v", err)
+	}
+	rateLimits := checkRateLimits(limitFlags.options(httpClient), clusterImages)
+	recordRateLimits(store, rateLimits)
+	if format == output.FormatTable {
+		ratelimit.PrintResults(rateLimits)
+	}
 
-	// 이미지 풀 이벤트 조회
-	pullEvents, err := kubernetes.GetPullEvents(sinceTime)
+	// 이미지 풀 이벤트 조회 (이력 저장소가 있으면 journal 보관 기간 이전의 이벤트도 포함)
+	pullEvents, err := getPullEvents(store, *since, "")
 	//pullEvents := []string{
 	//	`Feb 24 08:58:42 cp-dev-cluster1 crio[581]: time="2025-02-24 08:58:42.934122405+09:00" level=info msg="Pulled image: quay.io/calico/cni@sha256:4bf108485f738856b2a56dbcfb3848c8fb9161b97c967a7cd479a60855e13370" id=e9ccf295-4f1a-44c3-bd87-072e86392509 name=/runtime.v1.ImageService/PullImage`,
 	//	`Feb 24 08:58:44 cp-dev-cluster1 crio[581]: time="2025-02-24 08:58:44.138088507+09:00" level=info msg="Pulled image: registry.k8s.io/dns/k8s-dns-node-cache@sha256:b9c3ae254f65a9b0cd0c8c3f11a19c81b601561d388035d0770d6f9a41be15c5" id=125d8286-66b0-4d12-aa90-51b253e0aba7 name=/runtime.v1.ImageService/PullImage`,
@@ -127,8 +341,46 @@ func main() {
 		log.Fatalf("Failed to get pull events: %v", err)
 	}
 
-	// 이미지 풀 통계 출력
-	if err := kubernetes.PrintImagePullStatistics(kubeClient.GetClientset(), pullEvents, *since); err != nil {
-		log.Fatalf("Failed to print image pull statistics: %v", err)
+	// 이미지 크기 수집 (노드 이미지 목록, kubelet 이벤트, 레지스트리 매니페스트)
+	costPerGB, err := parseCostPerGB(*egressCost)
+	if err != nil {
+		log.Fatalf("Invalid --egress-cost: %v", err)
+	}
+	sizes := make(kubernetes.ImageSizes)
+	if *imageSizes {
+		sizes, err = kubernetes.GetImageSizes(kubeClient.GetClientset(), time.Now().Add(-time.Duration(*since)*time.Hour))
+		if err != nil {
+			log.Printf("Warning: Failed to get image sizes: %v", err)
+			sizes = make(kubernetes.ImageSizes)
+		}
+	}
+	if *registrySizes {
+		platform, err := parsePlatform(*sizePlatform)
+		if err != nil {
+			log.Fatalf("Invalid --size-platform: %v", err)
+		}
+		credentials, err := registry.DockerConfigCredentials(*dockerConfig)
+		if err != nil {
+			log.Fatalf("Failed to load registry credentials: %v", err)
+		}
+		registryClient := registry.NewClient(httpClient, credentials)
+		registryClient.Endpoints = registryEndpoints(*limitFlags.docker.registryURL)
+		addRegistrySizes(registryClient, sizes, pullEvents, platform)
+	}
+
+	// 이미지 풀 통계 계산
+	stats := kubernetes.NewPullStatistics(pullEvents, clusterImages, kubernetes.PullStatisticsOptions{
+		Since:     *since,
+		Sizes:     sizes,
+		CostPerGB: costPerGB,
+	})
+
+	// 결과 출력
+	if format == output.FormatTable {
+		kubernetes.PrintImagePullStatistics(stats)
+		return
+	}
+	if err := report.New(stats, rateLimits).Write(os.Stdout, format, table); err != nil {
+		log.Fatalf("Failed to write %s output: %v", format, err)
 	}
 }
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/mirror"
+)
+
+// runMirror 실행 중인 이미지를 모든 플랫폼과 함께 digest 그대로 대상 레지스트리로 복사
+func runMirror(args []string) {
+	fs := flag.NewFlagSet("mirror", flag.ExitOnError)
+	kubeconfig := fs.String("kubeconfig", defaultKubeconfig(),
+		"Absolute path to the kubeconfig file")
+	namespace := fs.String("namespace", "",
+		"Only mirror images of pods in this namespace (default: all namespaces)")
+	to := fs.String("to", "",
+		"Target registry as host[/prefix]; images are copied to <host>/<prefix>/<source registry>/<repository>")
+	concurrency := fs.Int("concurrency", 4,
+		"Number of images copied at the same time")
+	dryRun := fs.Bool("dry-run", false,
+		"Only show what would be copied without uploading")
+	registryOptions := addRegistryFlags(fs)
+	fs.Parse(args)
+
+	if *to == "" {
+		log.Fatalf("--to is required")
+	}
+	client, err := registryOptions.client()
+	if err != nil {
+		log.Fatalf("Failed to create registry client: %v", err)
+	}
+
+	kubeClient, err := kubernetes.NewKubeClient(*kubeconfig)
+	if err != nil {
+		log.Fatalf("Failed to create kubernetes client: %v", err)
+	}
+	containers, err := kubernetes.GetRunningContainers(kubeClient.GetClientset(), *namespace)
+	if err != nil {
+		log.Fatalf("Failed to get running containers: %v", err)
+	}
+
+	images, errs := mirror.InUseImages(containers, *to)
+	for _, err := range errs {
+		log.Printf("Warning: Skipping image: %s", err)
+	}
+
+	results := mirror.NewMirror(client, *concurrency, *dryRun).Run(context.Background(), images)
+	mirror.PrintResults(results, *dryRun)
+
+	for _, result := range results {
+		if result.Status == mirror.StatusFailed {
+			os.Exit(1)
+		}
+	}
+}
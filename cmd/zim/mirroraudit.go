@@ -0,0 +1,97 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/mirroraudit"
+	"github.com/suslmk-lee/zim-image-management/pkg/mirrorconfig"
+)
+
+// runMirrorAudit 노드의 CRI-O/containerd 미러 설정과 노드 풀 이벤트를 비교하여 upstream으로 직접 풀한 이미지를 출력
+func runMirrorAudit(args []string) {
+	fs := flag.NewFlagSet("mirror-audit", flag.ExitOnError)
+	hostname, _ := os.Hostname()
+	node := fs.String("node", hostname,
+		"Node name shown in the report (default: hostname)")
+	runtime := fs.String("runtime", "",
+		"Container runtime configuration to read: crio or containerd (default: crio if registries.conf exists)")
+	registriesConf := fs.String("registries-conf", mirroraudit.DefaultCRIOConfig+","+mirrorconfig.CRIODropInDir,
+		"Comma-separated CRI-O registries.conf files or drop-in directories")
+	certsDir := fs.String("certs-dir", mirrorconfig.ContainerdCertsDir,
+		"containerd config_path directory with <host>/hosts.toml files")
+	pullEventsFile := fs.String("pull-events", "",
+		"File with CRI-O \"Pulled image\" log lines (default: read journalctl on this node)")
+	since := fs.Int("since", 24,
+		"Check pull events from the last N hours when reading journalctl")
+	var mappings []string
+	fs.Func("mirror", "Expected mirror upstream=mirror[/path] (repeatable, default: mirrors configured on the node)",
+		func(value string) error {
+			mappings = append(mappings, value)
+			return nil
+		})
+	to := fs.String("to", "",
+		"Expected mirror registry used with zim mirror --to")
+	all := fs.Bool("all", false,
+		"Also list images pulled through the expected mirror")
+	failOnBypass := fs.Bool("fail-on-bypass", false,
+		"Exit with status 1 if any pull went straight upstream despite an expected mirror")
+	historyOptions := addHistoryFlags(fs)
+	fs.Parse(args)
+
+	mapping, err := mirrorconfig.ParseMapping(mappings)
+	if err != nil {
+		log.Fatalf("Invalid mirror mapping: %v", err)
+	}
+
+	if *runtime == "" {
+		*runtime = mirrorconfig.RuntimeCRIO
+		if _, err := os.Stat(mirroraudit.DefaultCRIOConfig); err != nil {
+			if _, err := os.Stat(*certsDir); err == nil {
+				*runtime = mirrorconfig.RuntimeContainerd
+			}
+		}
+	}
+	var config *mirroraudit.NodeConfig
+	switch *runtime {
+	case mirrorconfig.RuntimeCRIO:
+		config, err = mirroraudit.LoadCRIOConfig(splitList(*registriesConf))
+	case mirrorconfig.RuntimeContainerd:
+		config, err = mirroraudit.LoadContainerdConfig(*certsDir)
+	default:
+		log.Fatalf("Invalid runtime %q: expected crio or containerd", *runtime)
+	}
+	if err != nil {
+		log.Fatalf("Failed to read %s mirror configuration: %v", *runtime, err)
+	}
+
+	var pullEvents []string
+	if *pullEventsFile != "" {
+		data, err := os.ReadFile(*pullEventsFile)
+		if err != nil {
+			log.Fatalf("Failed to read pull events: %v", err)
+		}
+		pullEvents = strings.Split(string(data), "\n")
+	} else {
+		store, err := historyOptions.open()
+		if err != nil {
+			log.Fatalf("Failed to open history store: %v", err)
+		}
+		pullEvents, err = getPullEvents(store, *since, *node)
+		if err != nil {
+			log.Fatalf("Failed to get pull events: %v", err)
+		}
+	}
+
+	expected := mirroraudit.Expected(pullEvents, mirrorconfig.Options{Mapping: mapping, Target: *to})
+	audits := mirroraudit.Audit(config, pullEvents, expected)
+	mirroraudit.PrintAudit(*node, config, audits, *all)
+
+	if bypassed := mirroraudit.CountBypassed(audits); *failOnBypass && bypassed > 0 {
+		fmt.Fprintf(os.Stderr, "%d pulls on %s went straight to upstream registries\n", bypassed, *node)
+		os.Exit(1)
+	}
+}
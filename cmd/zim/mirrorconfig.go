@@ -0,0 +1,108 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/mirrorconfig"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// runMirrorConfig 미러 매핑과 풀 이벤트에서 관찰된 레지스트리로 CRI-O/containerd 미러 설정과 배포 번들을 생성
+func runMirrorConfig(args []string) {
+	fs := flag.NewFlagSet("mirror-config", flag.ExitOnError)
+	var mappings []string
+	fs.Func("mirror", "Mirror mapping upstream=mirror[/path], e.g. docker.io=registry.internal/docker.io (repeatable)",
+		func(value string) error {
+			mappings = append(mappings, value)
+			return nil
+		})
+	to := fs.String("to", "",
+		"Mirror registry used with zim mirror --to; observed registries without a mapping use <to>/<registry>")
+	since := fs.Int("since", 24,
+		"Include registries seen in pull events from the last N hours (0 to skip pull events)")
+	registries := fs.String("registries", "",
+		"Additional comma-separated upstream registries to configure")
+	runtimes := fs.String("runtime", mirrorconfig.RuntimeCRIO+","+mirrorconfig.RuntimeContainerd,
+		"Comma-separated container runtimes to generate configuration for (crio, containerd)")
+	insecure := fs.Bool("insecure", false,
+		"Mirrors serve plain HTTP (CRI-O insecure = true, containerd http:// host)")
+	outputDir := fs.String("output-dir", "zim-mirror-config",
+		"Directory to write the node files, machineconfig.yaml and daemonset.yaml into")
+	role := fs.String("machineconfig-role", "worker",
+		"MachineConfig pool role for machineconfig.yaml")
+	namespace := fs.String("namespace", "kube-system",
+		"Namespace of the ConfigMap and DaemonSet in daemonset.yaml")
+	installerImage := fs.String("installer-image", mirrorconfig.DefaultInstallerImage,
+		"Image used by the DaemonSet to copy the files onto nodes")
+	historyOptions := addHistoryFlags(fs)
+	fs.Parse(args)
+
+	mapping, err := mirrorconfig.ParseMapping(mappings)
+	if err != nil {
+		log.Fatalf("Invalid mirror mapping: %v", err)
+	}
+	if len(mapping) == 0 && *to == "" {
+		log.Fatalf("--mirror or --to is required")
+	}
+	selected := splitList(*runtimes)
+	for _, runtime := range selected {
+		if runtime != mirrorconfig.RuntimeCRIO && runtime != mirrorconfig.RuntimeContainerd {
+			log.Fatalf("Invalid runtime %q: expected crio or containerd", runtime)
+		}
+	}
+
+	observed := make(map[string]int)
+	if *since > 0 {
+		store, err := historyOptions.open()
+		if err != nil {
+			log.Fatalf("Failed to open history store: %v", err)
+		}
+		pullEvents, err := getPullEvents(store, *since, "")
+		if err != nil {
+			log.Printf("Warning: Failed to get pull events: %v", err)
+		}
+		observed = kubernetes.CountPullsByRegistry(pullEvents)
+	}
+	for _, host := range splitList(*registries) {
+		host = registry.NormalizeRegistry(host)
+		if _, ok := observed[host]; !ok {
+			observed[host] = 0
+		}
+	}
+
+	entries, unmapped := mirrorconfig.Entries(observed, mirrorconfig.Options{Mapping: mapping, Target: *to, Insecure: *insecure})
+	if len(entries) == 0 {
+		log.Fatalf("No registries to configure: no mapping matches the observed registries")
+	}
+	files := mirrorconfig.Generate(entries, selected)
+
+	written, err := mirrorconfig.WriteTree(*outputDir, files)
+	if err != nil {
+		log.Fatalf("Failed to write configuration: %v", err)
+	}
+	machineConfig, err := mirrorconfig.MachineConfig(files, *role)
+	if err != nil {
+		log.Fatalf("Failed to generate MachineConfig: %v", err)
+	}
+	daemonSet, err := mirrorconfig.DaemonSet(files, *namespace, *installerImage)
+	if err != nil {
+		log.Fatalf("Failed to generate DaemonSet: %v", err)
+	}
+	bundles := []struct {
+		name    string
+		content []byte
+	}{{"machineconfig.yaml", machineConfig}, {"daemonset.yaml", daemonSet}}
+	for _, bundle := range bundles {
+		path := filepath.Join(*outputDir, bundle.name)
+		if err := os.WriteFile(path, bundle.content, 0644); err != nil {
+			log.Fatalf("Failed to write %s: %v", path, err)
+		}
+		written = append(written, path)
+	}
+
+	mirrorconfig.PrintEntries(entries, unmapped, written)
+}
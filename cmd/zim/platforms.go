@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/multiarch"
+)
+
+// runPlatforms 사용 중인 이미지가 노드 아키텍처/OS를 모두 지원하는지 확인하여 출력
+func runPlatforms(args []string) {
+	fs := flag.NewFlagSet("platforms", flag.ExitOnError)
+	kubeconfig := fs.String("kubeconfig", defaultKubeconfig(),
+		"Absolute path to the kubeconfig file")
+	namespace := fs.String("namespace", "",
+		"Only check pods in this namespace (default: all namespaces)")
+	all := fs.Bool("all", false,
+		"Also list images that support every eligible node")
+	registryOptions := addRegistryFlags(fs)
+	fs.Parse(args)
+
+	client, err := registryOptions.client()
+	if err != nil {
+		log.Fatalf("Failed to create registry client: %v", err)
+	}
+
+	kubeClient, err := kubernetes.NewKubeClient(*kubeconfig)
+	if err != nil {
+		log.Fatalf("Failed to create kubernetes client: %v", err)
+	}
+	nodes, err := kubernetes.GetNodePlatforms(kubeClient.GetClientset())
+	if err != nil {
+		log.Fatalf("Failed to get node platforms: %v", err)
+	}
+	placements, err := kubernetes.GetPodPlacements(kubeClient.GetClientset(), *namespace, nodes)
+	if err != nil {
+		log.Fatalf("Failed to get pod placements: %v", err)
+	}
+
+	findings := multiarch.NewChecker(client).Check(context.Background(), nodes, placements)
+	multiarch.PrintFindings(nodes, findings, *all)
+}
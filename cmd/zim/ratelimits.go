@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/docker"
+	"github.com/suslmk-lee/zim-image-management/pkg/github"
+	"github.com/suslmk-lee/zim-image-management/pkg/harbor"
+	"github.com/suslmk-lee/zim-image-management/pkg/quay"
+	"github.com/suslmk-lee/zim-image-management/pkg/ratelimit"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// rateLimitOptions 레지스트리 rate limit 조회에 필요한 클라이언트와 인증 정보
+type rateLimitOptions struct {
+	httpClient        *http.Client
+	dockerClient      *docker.Client
+	dockerAuth        docker.DockerHubAuth
+	githubClient      *github.Client
+	githubToken       string
+	githubTokenSource string
+	ghcrUsername      string
+	ghcrImages        []string
+	quayClient        *quay.Client
+	harborClient      *harbor.Client
+}
+
+// rateLimitFlags 레지스트리 및 GitHub API rate limit 조회용 인증 정보와 엔드포인트 플래그
+type rateLimitFlags struct {
+	githubToken             *string
+	githubAppID             *int64
+	githubAppInstallationID *int64
+	githubAppPrivateKey     *string
+	ghcrUsername            *string
+	ghcrImages              *string
+	docker                  *dockerHubFlags
+	githubAPIURL            *string
+	quayURL                 *string
+	quayToken               *string
+	harborURL               *string
+	harborUsername          *string
+	harborPassword          *string
+}
+
+// addRateLimitFlags FlagSet에 rate limit 조회 관련 플래그를 등록
+func addRateLimitFlags(fs *flag.FlagSet) *rateLimitFlags {
+	return &rateLimitFlags{
+		githubToken: fs.String("github-token", "",
+			"GitHub token for GitHub API rate limits and authenticated ghcr.io checks"),
+		githubAppID: fs.Int64("github-app-id", 0,
+			"GitHub App ID used to create an installation token"),
+		githubAppInstallationID: fs.Int64("github-app-installation-id", 0,
+			"GitHub App installation ID used to create an installation token"),
+		githubAppPrivateKey: fs.String("github-app-private-key", "",
+			"Path to the GitHub App private key (PEM)"),
+		ghcrUsername: fs.String("ghcr-username", "",
+			"GitHub username used with --github-token for ghcr.io"),
+		ghcrImages: fs.String("ghcr-images", "",
+			"Comma-separated ghcr.io images to probe (default: ghcr.io images running in the cluster)"),
+		docker: addDockerHubFlags(fs),
+		githubAPIURL: fs.String("github-api-url", github.DefaultAPIURL,
+			"GitHub API endpoint"),
+		quayURL: fs.String("quay-url", quay.DefaultURL,
+			"Quay registry endpoint"),
+		quayToken: fs.String("quay-token", "",
+			"Quay API OAuth token for repository pull statistics"),
+		harborURL: fs.String("harbor-url", "",
+			"Harbor endpoint for project storage quota checks"),
+		harborUsername: fs.String("harbor-username", "",
+			"Harbor username (robot accounts supported)"),
+		harborPassword: fs.String("harbor-password", "",
+			"Harbor password or robot account secret"),
+	}
+}
+
+// dockerHubFlags Docker Hub rate limit 조회용 인증 정보와 엔드포인트 플래그
+type dockerHubFlags struct {
+	username    *string
+	password    *string
+	token       *string
+	authURL     *string
+	registryURL *string
+}
+
+// addDockerHubFlags FlagSet에 Docker Hub rate limit 조회 관련 플래그를 등록
+func addDockerHubFlags(fs *flag.FlagSet) *dockerHubFlags {
+	return &dockerHubFlags{
+		username: fs.String("docker-username", "",
+			"Docker Hub username for authenticated rate limit checking"),
+		password: fs.String("docker-password", "",
+			"Docker Hub password for authenticated rate limit checking"),
+		token: fs.String("docker-token", "",
+			"Docker Hub token (alternative to username/password)"),
+		authURL: fs.String("docker-auth-url", docker.DefaultAuthURL,
+			"Docker Hub token endpoint, used instead of the registry's token realm when changed"),
+		registryURL: fs.String("docker-registry-url", docker.DefaultRegistryURL,
+			"Docker Hub registry endpoint"),
+	}
+}
+
+// client 플래그의 엔드포인트를 사용하는 Docker Hub 클라이언트를 생성
+func (f *dockerHubFlags) client(httpClient *http.Client) *docker.Client {
+	client := docker.NewClient(httpClient)
+	client.AuthURL = *f.authURL
+	client.RegistryURL = *f.registryURL
+	return client
+}
+
+// auth 플래그의 Docker Hub 인증 정보 (모두 비어 있으면 익명)
+func (f *dockerHubFlags) auth() docker.DockerHubAuth {
+	return docker.DockerHubAuth{Username: *f.username, Password: *f.password, Token: *f.token}
+}
+
+// options 플래그 값으로 Provider 클라이언트를 구성 (GitHub 토큰 결정에 실패하면 경고 후 GitHub API 제외)
+// GitHub App 설치 토큰은 1시간 뒤 만료되므로 주기적으로 조회할 때는 매번 호출
+func (f *rateLimitFlags) options(httpClient *http.Client) rateLimitOptions {
+	// GitHub 토큰 결정 (플래그, 환경 변수 또는 GitHub App 설치 토큰)
+	githubClient := github.NewClient(httpClient)
+	githubClient.BaseURL = *f.githubAPIURL
+	githubToken, githubTokenSource, err := resolveGitHubToken(githubClient, *f.githubToken,
+		*f.githubAppID, *f.githubAppInstallationID, *f.githubAppPrivateKey)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Docker Hub 인증 정보 (인증된 사용자 또는 익명)
+	dockerClient := f.docker.client(httpClient)
+
+	quayClient := quay.NewClient(httpClient, *f.quayToken)
+	quayClient.BaseURL = *f.quayURL
+	var harborClient *harbor.Client
+	if *f.harborURL != "" {
+		harborClient = harbor.NewClient(httpClient, *f.harborURL, *f.harborUsername, *f.harborPassword)
+	}
+	var ghcrImages []string
+	if *f.ghcrImages != "" {
+		ghcrImages = strings.Split(*f.ghcrImages, ",")
+	}
+	return rateLimitOptions{
+		httpClient:        httpClient,
+		dockerClient:      dockerClient,
+		dockerAuth:        f.docker.auth(),
+		githubClient:      githubClient,
+		githubToken:       githubToken,
+		githubTokenSource: githubTokenSource,
+		ghcrUsername:      *f.ghcrUsername,
+		ghcrImages:        ghcrImages,
+		quayClient:        quayClient,
+		harborClient:      harborClient,
+	}
+}
+
+// groupImagesByRegistry 이미지 목록을 레지스트리 호스트별 참조 목록으로 분류 (중복 제거)
+func groupImagesByRegistry(images []string) map[string][]registry.Reference {
+	seen := make(map[string]bool)
+	byRegistry := make(map[string][]registry.Reference)
+	for _, image := range images {
+		ref, err := registry.ParseReference(image)
+		if err != nil || seen[ref.String()] {
+			continue
+		}
+		seen[ref.String()] = true
+		byRegistry[ref.Registry] = append(byRegistry[ref.Registry], ref)
+	}
+	return byRegistry
+}
+
+// buildRateLimitProviders 클러스터가 사용하는 레지스트리(항상 docker.io 포함)와 GitHub API에 대한 Provider 목록을 구성
+func buildRateLimitProviders(opts rateLimitOptions, clusterImages []string) []ratelimit.Provider {
+	byRegistry := groupImagesByRegistry(clusterImages)
+
+	hosts := []string{registry.DockerHubRegistry}
+	for host := range byRegistry {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	// ghcr.io는 지정된 이미지가 있으면 그것을, 없으면 클러스터 이미지를 조회
+	ghcrImages := byRegistry["ghcr.io"]
+	if len(opts.ghcrImages) > 0 {
+		ghcrImages = groupImagesByRegistry(opts.ghcrImages)["ghcr.io"]
+		hosts = append(hosts, "ghcr.io")
+	}
+
+	providers := ratelimit.NewProviderRegistry()
+	providers.Register(docker.NewRateLimitProvider(opts.dockerClient, opts.dockerAuth))
+	providers.Register(&github.GHCRProvider{
+		Client: github.NewGHCRClient(opts.httpClient, opts.ghcrUsername, opts.githubToken),
+		Images: ghcrImages,
+	})
+	quayHost := registry.NormalizeRegistry(opts.quayClient.BaseURL)
+	providers.Register(&quay.RateLimitProvider{Client: opts.quayClient, Images: byRegistry[quayHost]})
+	if opts.harborClient != nil {
+		harborHost := registry.NormalizeRegistry(opts.harborClient.BaseURL)
+		providers.Register(harbor.NewQuotaProvider(opts.harborClient, byRegistry[harborHost]))
+	}
+	providers.Fallback = func(host string) ratelimit.Provider {
+		images := byRegistry[host]
+		if len(images) == 0 {
+			return nil
+		}
+		return &ratelimit.GenericProvider{HTTPClient: opts.httpClient, Host: host, Image: images[0]}
+	}
+
+	selected := providers.ForRegistries(hosts)
+	if opts.githubToken != "" {
+		selected = append(selected, &github.RateLimitProvider{
+			Client:      opts.githubClient,
+			Token:       opts.githubToken,
+			TokenSource: opts.githubTokenSource,
+		})
+	}
+	return selected
+}
+
+// checkRateLimits 클러스터가 사용하는 레지스트리의 모든 Provider를 동시에 조회
+func checkRateLimits(opts rateLimitOptions, clusterImages []string) []ratelimit.Result {
+	providers := buildRateLimitProviders(opts, clusterImages)
+	return ratelimit.CheckAll(context.Background(), providers)
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/collector"
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/ratelimit"
+	"github.com/suslmk-lee/zim-image-management/pkg/server"
+)
+
+// runServe 풀 이벤트, 사용 중 이미지, rate limit을 주기적으로 수집하여 Prometheus 메트릭, JSON API, 대시보드로 제공
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	kubeconfig := fs.String("kubeconfig", defaultKubeconfig(),
+		"Absolute path to the kubeconfig file")
+	metricsAddr := fs.String("metrics-addr", ":9090",
+		"Address to serve Prometheus metrics (/metrics), the JSON API (/api/v1) and the dashboard (/) on")
+	interval := fs.Duration("interval", 5*time.Minute,
+		"Time between collections")
+	since := fs.Int("since", 24,
+		"Statistics window in hours (the first collection counts pulls from this window)")
+	hostname, _ := os.Hostname()
+	node := fs.String("node", hostname,
+		"Node label for pull events without a journal hostname (default: hostname)")
+	imageSizes := fs.Bool("image-sizes", true,
+		"Estimate data pulled from node image sizes and kubelet \"Image size\" events")
+	egressCost := fs.String("egress-cost", "",
+		"Egress cost in $/GB per registry, e.g. docker.io=0.09,default=0.05")
+	rateLimits := fs.Bool("rate-limits", true,
+		"Check registry rate limits on every collection")
+	limitFlags := addRateLimitFlags(fs)
+	httpOptions := addHTTPFlags(fs)
+	historyOptions := addHistoryFlags(fs)
+	fs.Parse(args)
+
+	if *interval <= 0 {
+		log.Fatalf("Invalid --interval: must be positive")
+	}
+	costPerGB, err := parseCostPerGB(*egressCost)
+	if err != nil {
+		log.Fatalf("Invalid --egress-cost: %v", err)
+	}
+	httpClient, err := httpOptions.client()
+	if err != nil {
+		log.Fatalf("Failed to create HTTP client: %v", err)
+	}
+	kubeClient, err := kubernetes.NewKubeClient(*kubeconfig)
+	if err != nil {
+		log.Fatalf("Failed to create kubernetes client: %v", err)
+	}
+
+	config := collector.Config{
+		Clientset:  kubeClient.GetClientset(),
+		Since:      *since,
+		Node:       *node,
+		ImageSizes: *imageSizes,
+		CostPerGB:  costPerGB,
+	}
+	store, err := historyOptions.open()
+	if err != nil {
+		log.Fatalf("Failed to open history store: %v", err)
+	}
+	if store != nil {
+		config.PullEvents = func(string) ([]string, error) {
+			// 오래 실행되는 동안에도 보관 기간이 지난 세그먼트를 정리
+			if _, err := store.Prune(time.Now()); err != nil {
+				log.Printf("Warning: Failed to prune history: %v", err)
+			}
+			return getPullEvents(store, *since, *node)
+		}
+	}
+	if *rateLimits {
+		config.RateLimits = func(clusterImages []string) []ratelimit.Result {
+			results := checkRateLimits(limitFlags.options(httpClient), clusterImages)
+			recordRateLimits(store, results)
+			return results
+		}
+	}
+	c := collector.New(config)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go collectLoop(ctx, c, *interval)
+
+	srv := &http.Server{Addr: *metricsAddr, Handler: server.New(c).Handler()}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+	log.Printf("Serving dashboard, metrics and API on %s (collecting every %s)", *metricsAddr, *interval)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("Failed to serve metrics: %v", err)
+	}
+}
+
+// collectLoop 종료될 때까지 interval마다 수집하고 실패한 항목을 경고로 기록
+func collectLoop(ctx context.Context, c *collector.Collector, interval time.Duration) {
+	for {
+		snapshot := c.Collect()
+		for _, message := range snapshot.Errors {
+			log.Printf("Warning: %s", message)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"crypto"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/signature"
+)
+
+// runSignatures 실행 중인 이미지 digest의 cosign 서명과 attestation 존재 여부(및 공개 키 검증)를 출력
+func runSignatures(args []string) {
+	fs := flag.NewFlagSet("signatures", flag.ExitOnError)
+	kubeconfig := fs.String("kubeconfig", defaultKubeconfig(),
+		"Absolute path to the kubeconfig file")
+	namespace := fs.String("namespace", "",
+		"Only check pods in this namespace (default: all namespaces)")
+	keyPath := fs.String("key", "",
+		"PEM public key (e.g. cosign.pub) used to verify cosign signatures")
+	requireSigned := fs.Bool("require-signed", false,
+		"Exit with status 1 if any running image is unsigned or fails verification")
+	all := fs.Bool("all", false,
+		"Also list signed and attested images")
+	registryOptions := addRegistryFlags(fs)
+	fs.Parse(args)
+
+	var publicKey crypto.PublicKey
+	if *keyPath != "" {
+		key, err := signature.LoadPublicKey(*keyPath)
+		if err != nil {
+			log.Fatalf("Failed to load public key: %v", err)
+		}
+		publicKey = key
+	}
+	client, err := registryOptions.client()
+	if err != nil {
+		log.Fatalf("Failed to create registry client: %v", err)
+	}
+
+	kubeClient, err := kubernetes.NewKubeClient(*kubeconfig)
+	if err != nil {
+		log.Fatalf("Failed to create kubernetes client: %v", err)
+	}
+	containers, err := kubernetes.GetRunningContainers(kubeClient.GetClientset(), *namespace)
+	if err != nil {
+		log.Fatalf("Failed to get running containers: %v", err)
+	}
+
+	signatures := signature.NewChecker(client, publicKey).Check(context.Background(), containers)
+	signature.PrintSignatures(signatures, *all)
+
+	if unsigned := signature.CountUnsigned(signatures); *requireSigned && unsigned > 0 {
+		fmt.Fprintf(os.Stderr, "%d running images are unsigned or failed verification\n", unsigned)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// parseCostPerGB "docker.io=0.09,default=0.05" 형식의 레지스트리별 GB당 비용을 파싱
+func parseCostPerGB(value string) (map[string]float64, error) {
+	costs := make(map[string]float64)
+	if value == "" {
+		return costs, nil
+	}
+	for _, entry := range strings.Split(value, ",") {
+		host, price, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid egress cost %q: expected registry=price", entry)
+		}
+		cost, err := strconv.ParseFloat(price, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid egress cost for %s: %v", host, err)
+		}
+		if host != "default" {
+			host = registry.NormalizeRegistry(host)
+		}
+		costs[host] = cost
+	}
+	return costs, nil
+}
+
+// parsePlatform "os/arch[/variant]" 형식의 플랫폼 문자열을 파싱
+func parsePlatform(value string) (registry.Platform, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return registry.Platform{}, fmt.Errorf("invalid platform %q: expected os/arch[/variant]", value)
+	}
+	platform := registry.Platform{OS: parts[0], Architecture: parts[1]}
+	if len(parts) == 3 {
+		platform.Variant = parts[2]
+	}
+	return platform, nil
+}
+
+// addRegistrySizes 최근 풀 이벤트의 이미지 참조로 레지스트리 매니페스트를 조회하여 압축 크기를 추가
+func addRegistrySizes(client *registry.Client, sizes kubernetes.ImageSizes, pullEvents []string, platform registry.Platform) {
+	ctx := context.Background()
+	for name, ref := range kubernetes.LatestPulledReferences(pullEvents) {
+		manifest, err := client.GetManifest(ctx, ref)
+		if err != nil {
+			log.Printf("Warning: Failed to get manifest for %s: %v", ref, err)
+			continue
+		}
+		manifest, err = client.PlatformManifest(ctx, ref, manifest, platform)
+		if err != nil {
+			log.Printf("Warning: %v", err)
+			continue
+		}
+		sizes.Add(name, manifest.CompressedSize(), kubernetes.SizeSourceRegistry)
+	}
+}
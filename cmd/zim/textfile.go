@@ -0,0 +1,87 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/collector"
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/metrics"
+	"github.com/suslmk-lee/zim-image-management/pkg/ratelimit"
+)
+
+// runTextfile 노드의 풀 통계와 rate limit을 한 번 수집하여 node_exporter textfile collector용 .prom 파일로 기록
+func runTextfile(args []string) {
+	fs := flag.NewFlagSet("textfile", flag.ExitOnError)
+	dir := fs.String("dir", "/var/lib/node_exporter/textfile_collector",
+		"node_exporter --collector.textfile.directory")
+	name := fs.String("name", "zim.prom",
+		"File name written in --dir (must end with .prom)")
+	kubeconfig := fs.String("kubeconfig", "",
+		"kubeconfig for in-use images and image sizes (default: node-local pull events and rate limits only)")
+	since := fs.Int("since", 24,
+		"Statistics window in hours")
+	hostname, _ := os.Hostname()
+	node := fs.String("node", hostname,
+		"Node name used for pull events without a journal hostname (default: hostname)")
+	egressCost := fs.String("egress-cost", "",
+		"Egress cost in $/GB per registry, e.g. docker.io=0.09,default=0.05")
+	rateLimits := fs.Bool("rate-limits", true,
+		"Check registry rate limits from this node (Docker Hub limits are per source IP)")
+	limitFlags := addRateLimitFlags(fs)
+	httpOptions := addHTTPFlags(fs)
+	historyOptions := addHistoryFlags(fs)
+	fs.Parse(args)
+
+	if !strings.HasSuffix(*name, ".prom") || strings.Contains(*name, "/") {
+		log.Fatalf("Invalid --name %q: must be a file name ending with .prom", *name)
+	}
+	costPerGB, err := parseCostPerGB(*egressCost)
+	if err != nil {
+		log.Fatalf("Invalid --egress-cost: %v", err)
+	}
+	httpClient, err := httpOptions.client()
+	if err != nil {
+		log.Fatalf("Failed to create HTTP client: %v", err)
+	}
+
+	config := collector.Config{Since: *since, Node: *node, CostPerGB: costPerGB}
+	if *kubeconfig != "" {
+		kubeClient, err := kubernetes.NewKubeClient(*kubeconfig)
+		if err != nil {
+			log.Fatalf("Failed to create kubernetes client: %v", err)
+		}
+		config.Clientset = kubeClient.GetClientset()
+		config.ImageSizes = true
+	}
+	store, err := historyOptions.open()
+	if err != nil {
+		log.Fatalf("Failed to open history store: %v", err)
+	}
+	if store != nil {
+		config.PullEvents = func(string) ([]string, error) {
+			return getPullEvents(store, *since, *node)
+		}
+	}
+	if *rateLimits {
+		config.RateLimits = func(clusterImages []string) []ratelimit.Result {
+			results := checkRateLimits(limitFlags.options(httpClient), clusterImages)
+			recordRateLimits(store, results)
+			return results
+		}
+	}
+
+	snapshot := collector.New(config).Collect()
+	for _, message := range snapshot.Errors {
+		log.Printf("Warning: %s", message)
+	}
+
+	// 한 번 실행하는 모드에서는 누적 카운터가 의미가 없으므로 기간 기준 gauge만 기록
+	path := filepath.Join(*dir, *name)
+	if err := metrics.WriteFile(path, collector.SnapshotMetrics(snapshot)); err != nil {
+		log.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
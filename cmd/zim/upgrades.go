@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+	"github.com/suslmk-lee/zim-image-management/pkg/upgrade"
+)
+
+// runUpgrades 사용 중인 이미지마다 레지스트리에 있는 새 semver 버전을 워크로드별로 출력
+func runUpgrades(args []string) {
+	fs := flag.NewFlagSet("upgrades", flag.ExitOnError)
+	kubeconfig := fs.String("kubeconfig", defaultKubeconfig(),
+		"Absolute path to the kubeconfig file")
+	namespace := fs.String("namespace", "",
+		"Only check pods in this namespace (default: all namespaces)")
+	registries := fs.String("registries", "",
+		"Comma-separated registries to check (default: all registries)")
+	excludeRegistries := fs.String("exclude-registries", "",
+		"Comma-separated registries to skip")
+	all := fs.Bool("all", false,
+		"Also list images that are already on the newest version")
+	patterns := make(map[string]*regexp.Regexp)
+	fs.Func("tag-pattern",
+		"Regular expression candidate tags must match for an image, as image=regex (repeatable)",
+		func(value string) error {
+			return addTagPattern(patterns, value)
+		})
+	registryOptions := addRegistryFlags(fs)
+	fs.Parse(args)
+
+	client, err := registryOptions.client()
+	if err != nil {
+		log.Fatalf("Failed to create registry client: %v", err)
+	}
+
+	kubeClient, err := kubernetes.NewKubeClient(*kubeconfig)
+	if err != nil {
+		log.Fatalf("Failed to create kubernetes client: %v", err)
+	}
+	images, err := kubernetes.GetWorkloadImages(kubeClient.GetClientset(), *namespace)
+	if err != nil {
+		log.Fatalf("Failed to get workload images: %v", err)
+	}
+
+	checker := upgrade.NewChecker(client)
+	checker.Patterns = patterns
+	for _, host := range splitList(*registries) {
+		checker.Registries[registry.NormalizeRegistry(host)] = true
+	}
+	for _, host := range splitList(*excludeRegistries) {
+		checker.ExcludeRegistries[registry.NormalizeRegistry(host)] = true
+	}
+
+	updates := checker.Check(context.Background(), images)
+	upgrade.PrintUpgrades(updates, *all)
+}
+
+// addTagPattern "image=regex" 형식의 태그 패턴을 정규화된 이미지 이름으로 등록
+func addTagPattern(patterns map[string]*regexp.Regexp, value string) error {
+	image, expr, ok := strings.Cut(value, "=")
+	if !ok {
+		return fmt.Errorf("expected image=regex")
+	}
+	ref, err := registry.ParseReference(image)
+	if err != nil {
+		return err
+	}
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return err
+	}
+	patterns[ref.Name()] = pattern
+	return nil
+}
+
+// splitList 쉼표로 구분된 목록을 공백을 제거하여 분리 (빈 항목 제외)
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/vulns"
+)
+
+// runVulns 스캐너 리포트와 SBOM을 읽어 실행 중인 이미지와 연결하고 Pod 수와 풀 횟수로 가중하여 출력
+func runVulns(args []string) {
+	fs := flag.NewFlagSet("vulns", flag.ExitOnError)
+	kubeconfig := fs.String("kubeconfig", defaultKubeconfig(),
+		"Absolute path to the kubeconfig file")
+	namespace := fs.String("namespace", "",
+		"Only match pods in this namespace (default: all namespaces)")
+	since := fs.Int("since", 24,
+		"Count pull events from the last N hours (default: 24)")
+	var paths []string
+	fs.Func("report",
+		"Trivy/Grype JSON report or CycloneDX/SPDX JSON SBOM; comma-separated, glob or repeatable",
+		func(value string) error {
+			for _, pattern := range splitList(value) {
+				matches, err := filepath.Glob(pattern)
+				if err != nil {
+					return err
+				}
+				if matches == nil {
+					matches = []string{pattern}
+				}
+				paths = append(paths, matches...)
+			}
+			return nil
+		})
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s vulns --report <files> [options] [files...]\n\nOptions:\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	historyOptions := addHistoryFlags(fs)
+	fs.Parse(args)
+	paths = append(paths, fs.Args()...)
+
+	if len(paths) == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	var reports []*vulns.Report
+	for _, path := range paths {
+		report, err := vulns.LoadReport(path)
+		if err != nil {
+			log.Printf("Warning: %v", err)
+			continue
+		}
+		reports = append(reports, report)
+	}
+	if len(reports) == 0 {
+		log.Fatalf("No readable reports")
+	}
+
+	kubeClient, err := kubernetes.NewKubeClient(*kubeconfig)
+	if err != nil {
+		log.Fatalf("Failed to create kubernetes client: %v", err)
+	}
+	containers, err := kubernetes.GetRunningContainers(kubeClient.GetClientset(), *namespace)
+	if err != nil {
+		log.Fatalf("Failed to get running containers: %v", err)
+	}
+
+	// 풀 이벤트가 없어도 실행 중인 Pod 기준으로 출력
+	store, err := historyOptions.open()
+	if err != nil {
+		log.Fatalf("Failed to open history store: %v", err)
+	}
+	pullEvents, err := getPullEvents(store, *since, "")
+	if err != nil {
+		log.Printf("Warning: Failed to get pull events: %v", err)
+	}
+
+	exposures, unmatched, missing := vulns.MatchReports(reports, containers, kubernetes.CountPullsByImage(pullEvents))
+	vulns.PrintExposures(exposures, unmatched, missing, *since)
+}
@@ -0,0 +1,244 @@
+package collector
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/metrics"
+	"github.com/suslmk-lee/zim-image-management/pkg/ratelimit"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+// DurationBuckets 이미지 풀 소요 시간 히스토그램 버킷 (초)
+var DurationBuckets = []float64{0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600}
+
+// Config 주기적인 수집 설정
+type Config struct {
+	Clientset *k8s.Clientset
+	// Since 풀 통계 기간 (시간)
+	Since int
+	// Node 호스트 이름이 없는 풀 이벤트 로그에 사용할 노드 이름
+	Node string
+	// ImageSizes 노드 이미지 목록과 kubelet 이벤트로 전송량을 추정할지 여부
+	ImageSizes bool
+	// CostPerGB 레지스트리별 GB당 egress 비용
+	CostPerGB map[string]float64
+	// PullEvents 풀 이벤트 로그 조회 (nil이면 journalctl)
+	PullEvents func(since string) ([]string, error)
+	// RateLimits 사용 중인 이미지 목록으로 레지스트리 rate limit 조회 (nil이면 조회하지 않음)
+	RateLimits func(clusterImages []string) []ratelimit.Result
+}
+
+// Snapshot 수집 주기 한 번의 결과 (다음 수집 전까지 그대로 제공)
+type Snapshot struct {
+	CollectedAt time.Time
+	Duration    time.Duration
+	PullEvents  []string
+	// Pulls 파싱한 풀 이벤트 (journal 호스트 이름이 없으면 Config.Node)
+	Pulls         []kubernetes.PullEvent
+	ClusterImages []string
+	// Containers 실행 중인 컨테이너와 노드 (노드별 이미지 목록용)
+	Containers   []kubernetes.RunningContainer
+	KubeletPulls []kubernetes.KubeletPullEvent
+	Stats        *kubernetes.PullStatistics
+	// Sizes, CostPerGB 풀 통계 계산에 사용한 이미지 크기와 비용 (짧은 기간의 통계를 다시 계산할 때 사용)
+	Sizes      kubernetes.ImageSizes
+	CostPerGB  map[string]float64
+	RateLimits []ratelimit.Result
+	// Errors 수집 중 실패한 항목 (실패한 항목을 제외한 나머지 결과는 유효)
+	Errors []string
+}
+
+// StatisticsSince 마지막 hours 시간의 풀 이벤트로 CLI와 같은 풀 통계를 계산 (시각을 모르는 이벤트는 포함)
+func (s *Snapshot) StatisticsSince(hours int) *kubernetes.PullStatistics {
+	from := s.CollectedAt.Add(-time.Duration(hours) * time.Hour)
+	var events []string
+	for _, line := range s.PullEvents {
+		if event, ok := kubernetes.ParsePullEvent(line); ok && (event.Time.IsZero() || !event.Time.Before(from)) {
+			events = append(events, line)
+		}
+	}
+	return kubernetes.NewPullStatistics(events, s.ClusterImages, kubernetes.PullStatisticsOptions{
+		Since:     hours,
+		Sizes:     s.Sizes,
+		CostPerGB: s.CostPerGB,
+	})
+}
+
+// pullKey 누적 풀 횟수 레이블
+type pullKey struct {
+	image    string
+	registry string
+	node     string
+}
+
+// durationKey 풀 소요 시간 히스토그램 레이블
+type durationKey struct {
+	registry string
+	node     string
+}
+
+// Collector 풀 이벤트, 사용 중 이미지, rate limit을 주기적으로 수집하고 누적 메트릭을 유지
+type Collector struct {
+	config Config
+
+	mu       sync.RWMutex
+	snapshot *Snapshot
+	// seenEvents 이전 수집에서 본 풀 이벤트별 횟수 (중복 집계 방지, 키는 pullEventKey)
+	seenEvents map[string]int
+	// seenKubelet 이전 수집에서 본 kubelet Pulled 이벤트
+	seenKubelet map[string]bool
+	pulls       map[pullKey]int
+	durations   map[durationKey]*metrics.HistogramValue
+	collections int
+	errors      int
+}
+
+// New 수집기를 생성 (첫 Collect 전까지 Snapshot은 nil)
+func New(config Config) *Collector {
+	if config.PullEvents == nil {
+		config.PullEvents = kubernetes.GetPullEvents
+	}
+	return &Collector{
+		config:      config,
+		seenEvents:  make(map[string]int),
+		seenKubelet: make(map[string]bool),
+		pulls:       make(map[pullKey]int),
+		durations:   make(map[durationKey]*metrics.HistogramValue),
+	}
+}
+
+// Snapshot 마지막 수집 결과 (아직 수집하지 않았으면 nil)
+func (c *Collector) Snapshot() *Snapshot {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.snapshot
+}
+
+// Collect 한 번 수집하여 Snapshot을 교체하고 새 풀 이벤트를 누적 카운터에 더함
+// 일부 항목이 실패해도 나머지 결과로 Snapshot을 만들고 실패 내용은 Snapshot.Errors에 기록
+func (c *Collector) Collect() *Snapshot {
+	start := time.Now()
+	snapshot := &Snapshot{CollectedAt: start, CostPerGB: c.config.CostPerGB}
+	since := start.Add(-time.Duration(c.config.Since) * time.Hour)
+
+	pullEvents, err := c.config.PullEvents(fmt.Sprintf("%dh ago", c.config.Since))
+	if err != nil && !kubernetes.IsNoPullEvents(err) {
+		snapshot.Errors = append(snapshot.Errors, fmt.Sprintf("failed to get pull events: %v", err))
+	}
+	snapshot.PullEvents = pullEvents
+	snapshot.Pulls = c.parsePullEvents(pullEvents)
+
+	if c.config.Clientset != nil {
+		snapshot.ClusterImages, err = kubernetes.GetPodImages(c.config.Clientset)
+		if err != nil {
+			snapshot.Errors = append(snapshot.Errors, fmt.Sprintf("failed to get cluster images: %v", err))
+		}
+		snapshot.Containers, err = kubernetes.GetRunningContainers(c.config.Clientset, "")
+		if err != nil {
+			snapshot.Errors = append(snapshot.Errors, fmt.Sprintf("failed to get running containers: %v", err))
+		}
+		snapshot.KubeletPulls, err = kubernetes.GetKubeletPullEvents(c.config.Clientset, since)
+		if err != nil {
+			snapshot.Errors = append(snapshot.Errors, fmt.Sprintf("failed to get kubelet pull events: %v", err))
+		}
+		if c.config.ImageSizes {
+			snapshot.Sizes, err = kubernetes.GetImageSizes(c.config.Clientset, since)
+			if err != nil {
+				snapshot.Errors = append(snapshot.Errors, fmt.Sprintf("failed to get image sizes: %v", err))
+			}
+		}
+	}
+	snapshot.Stats = snapshot.StatisticsSince(c.config.Since)
+
+	if c.config.RateLimits != nil {
+		snapshot.RateLimits = c.config.RateLimits(snapshot.ClusterImages)
+	}
+	snapshot.Duration = time.Since(start)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.addPullEvents(pullEvents)
+	c.addKubeletPulls(snapshot.KubeletPulls)
+	c.collections++
+	if len(snapshot.Errors) > 0 {
+		c.errors++
+	}
+	c.snapshot = snapshot
+	return snapshot
+}
+
+// parsePullEvents 로그 라인을 풀 이벤트로 변환 (호스트 이름이 없는 라인은 Config.Node)
+func (c *Collector) parsePullEvents(pullEvents []string) []kubernetes.PullEvent {
+	var pulls []kubernetes.PullEvent
+	for _, line := range pullEvents {
+		event, ok := kubernetes.ParsePullEvent(line)
+		if !ok {
+			continue
+		}
+		if event.Node == "" {
+			event.Node = c.config.Node
+		}
+		pulls = append(pulls, event)
+	}
+	return pulls
+}
+
+// addPullEvents 이전 수집에서 보지 못한 풀 이벤트만 누적 풀 횟수에 더함
+// 같은 키의 라인이 여러 번 나오면(요청 ID가 없고 같은 초에 반복된 풀) 이전 수집보다 늘어난 만큼만 더함
+// 수집 기간을 벗어난 라인은 다시 조회되지 않으므로 이번에 조회한 라인만 기억
+func (c *Collector) addPullEvents(pullEvents []string) {
+	seen := make(map[string]int, len(pullEvents))
+	for _, line := range pullEvents {
+		event, ok := kubernetes.ParsePullEvent(line)
+		if !ok {
+			continue
+		}
+		key := pullEventKey(event, line)
+		seen[key]++
+		if seen[key] <= c.seenEvents[key] {
+			continue
+		}
+		node := event.Node
+		if node == "" {
+			node = c.config.Node
+		}
+		c.pulls[pullKey{image: event.Reference.Name(), registry: event.Reference.Registry, node: node}]++
+	}
+	c.seenEvents = seen
+}
+
+// pullEventKey 수집 사이에 같은 풀 이벤트를 찾는 키 (CRI-O 요청 ID가 있으면 노드와 ID, 없으면 로그 라인)
+func pullEventKey(event kubernetes.PullEvent, line string) string {
+	if event.ID != "" {
+		return "id|" + event.Node + "|" + event.ID
+	}
+	return "line|" + line
+}
+
+// addKubeletPulls 새 kubelet Pulled 이벤트의 소요 시간을 레지스트리/노드별 히스토그램에 기록
+func (c *Collector) addKubeletPulls(pulls []kubernetes.KubeletPullEvent) {
+	seen := make(map[string]bool, len(pulls))
+	for _, pull := range pulls {
+		key := fmt.Sprintf("%s/%s/%s/%s/%d", pull.Node, pull.Namespace, pull.Pod, pull.Image, pull.Time.UnixNano())
+		seen[key] = true
+		if c.seenKubelet[key] {
+			continue
+		}
+		ref, err := registry.ParseReference(pull.Image)
+		if err != nil {
+			continue
+		}
+		dk := durationKey{registry: ref.Registry, node: pull.Node}
+		histogram, ok := c.durations[dk]
+		if !ok {
+			histogram = metrics.NewHistogram(DurationBuckets)
+			c.durations[dk] = histogram
+		}
+		histogram.Observe(pull.Duration.Seconds())
+	}
+	c.seenKubelet = seen
+}
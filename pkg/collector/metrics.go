@@ -0,0 +1,108 @@
+package collector
+
+import (
+	"github.com/suslmk-lee/zim-image-management/pkg/metrics"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// Metrics 누적 카운터와 마지막 Snapshot으로 Prometheus 메트릭을 구성
+func (c *Collector) Metrics() []metrics.Family {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	pulls := metrics.Family{Name: "zim_image_pulls_total", Type: metrics.Counter,
+		Help: "Image pulls seen in CRI-O pull events since zim serve started (the first collection counts the --since window)."}
+	for key, count := range c.pulls {
+		pulls.Add(float64(count), "image", key.image, "registry", key.registry, "node", key.node)
+	}
+	durations := metrics.Family{Name: "zim_pull_duration_seconds", Type: metrics.Histogram,
+		Help: "Image pull duration reported by kubelet Pulled events."}
+	for key, histogram := range c.durations {
+		durations.AddHistogram(histogram.Copy(), "registry", key.registry, "node", key.node)
+	}
+	collections := metrics.Family{Name: "zim_collections_total", Type: metrics.Counter,
+		Help: "Completed collection cycles."}
+	collections.Add(float64(c.collections))
+	collectionErrors := metrics.Family{Name: "zim_collection_errors_total", Type: metrics.Counter,
+		Help: "Collection cycles in which at least one source failed."}
+	collectionErrors.Add(float64(c.errors))
+
+	families := []metrics.Family{pulls, durations}
+	if c.snapshot != nil {
+		families = append(families, SnapshotMetrics(c.snapshot)...)
+	}
+	return append(families, collections, collectionErrors)
+}
+
+// SnapshotMetrics 수집 결과 하나로 만들 수 있는 gauge 메트릭 (사용 중 이미지, 전송량, rate limit, 수집 시각)
+func SnapshotMetrics(snapshot *Snapshot) []metrics.Family {
+	inUse := metrics.Family{Name: "zim_images_in_use", Type: metrics.Gauge,
+		Help: "Containers and init containers in the cluster using the image."}
+	containers := make(map[string]int)
+	for _, image := range snapshot.ClusterImages {
+		if ref, err := registry.ParseReference(image); err == nil {
+			containers[ref.Name()]++
+		}
+	}
+	for name, count := range containers {
+		ref, _ := registry.ParseReference(name)
+		inUse.Add(float64(count), "image", name, "registry", ref.Registry)
+	}
+
+	windowPulls := metrics.Family{Name: "zim_image_window_pulls", Type: metrics.Gauge,
+		Help: "Image pulls in the statistics window (--since hours)."}
+	bytesPulled := metrics.Family{Name: "zim_image_pulled_bytes", Type: metrics.Gauge,
+		Help: "Pulls times image size in the statistics window (uncompressed node sizes, an upper bound on registry egress)."}
+	if snapshot.Stats != nil {
+		for _, stat := range snapshot.Stats.Images {
+			windowPulls.Add(float64(stat.Pulls), "image", stat.Image, "registry", stat.Registry)
+			if stat.SizeBytes > 0 {
+				bytesPulled.Add(float64(stat.BytesPulled), "image", stat.Image, "registry", stat.Registry)
+			}
+		}
+	}
+
+	limit := metrics.Family{Name: "zim_registry_ratelimit_limit", Type: metrics.Gauge,
+		Help: "Registry or API rate limit in the current window."}
+	remaining := metrics.Family{Name: "zim_registry_ratelimit_remaining", Type: metrics.Gauge,
+		Help: "Requests or pulls remaining in the current rate limit window."}
+	throttled := metrics.Family{Name: "zim_registry_ratelimit_throttled", Type: metrics.Gauge,
+		Help: "1 if the registry answered the rate limit check with throttling."}
+	up := metrics.Family{Name: "zim_registry_ratelimit_up", Type: metrics.Gauge,
+		Help: "1 if the rate limit check of the provider succeeded."}
+	for _, result := range snapshot.RateLimits {
+		if result.Err != nil {
+			up.Add(0, "provider", result.Provider)
+			continue
+		}
+		up.Add(1, "provider", result.Provider)
+		for _, quota := range result.Quotas {
+			labels := []string{"registry", quota.Registry, "resource", quota.Resource, "account", quota.Identity}
+			if quota.HasLimit() {
+				limit.Add(float64(quota.Limit), labels...)
+				remaining.Add(float64(quota.Remaining), labels...)
+			}
+			throttled.Add(boolValue(quota.Throttled), labels...)
+		}
+	}
+
+	timestamp := metrics.Family{Name: "zim_collection_timestamp_seconds", Type: metrics.Gauge,
+		Help: "Unix time of the last collection."}
+	timestamp.Add(float64(snapshot.CollectedAt.UnixNano()) / 1e9)
+	duration := metrics.Family{Name: "zim_collection_duration_seconds", Type: metrics.Gauge,
+		Help: "Duration of the last collection."}
+	duration.Add(snapshot.Duration.Seconds())
+	failed := metrics.Family{Name: "zim_collection_failed_sources", Type: metrics.Gauge,
+		Help: "Sources (pull events, pods, events, image sizes) that failed in the last collection."}
+	failed.Add(float64(len(snapshot.Errors)))
+
+	return []metrics.Family{inUse, windowPulls, bytesPulled, limit, remaining, throttled, up, timestamp, duration, failed}
+}
+
+// boolValue bool을 메트릭 값(1 또는 0)으로 변환
+func boolValue(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,37 @@
+package docker
+
+import (
+	"net/http"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/httpclient"
+)
+
+const (
+	// DefaultAuthURL Docker Hub 토큰 발급 엔드포인트 (레지스트리 challenge의 realm과 같으므로 기본값이면 challenge를 따름)
+	DefaultAuthURL = "https://auth.docker.io/token"
+	// DefaultRegistryURL Docker Hub 레지스트리 엔드포인트
+	DefaultRegistryURL = "https://registry-1.docker.io"
+
+	// rateLimitRepository rate limit 조회용 테스트 저장소
+	rateLimitRepository = "ratelimitpreview/test"
+)
+
+// Client Docker Hub API 클라이언트
+type Client struct {
+	HTTPClient *http.Client
+	// AuthURL 토큰 발급 엔드포인트 (DefaultAuthURL이 아니면 레지스트리 challenge의 realm 대신 사용)
+	AuthURL     string
+	RegistryURL string
+}
+
+// NewClient 기본 Docker Hub 엔드포인트를 사용하는 클라이언트를 생성 (httpClient가 nil이면 기본 클라이언트 사용)
+func NewClient(httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = httpclient.Default()
+	}
+	return &Client{
+		HTTPClient:  httpClient,
+		AuthURL:     DefaultAuthURL,
+		RegistryURL: DefaultRegistryURL,
+	}
+}
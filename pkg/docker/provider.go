@@ -0,0 +1,62 @@
+package docker
+
+import (
+	"context"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/ratelimit"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// RateLimitProvider Docker Hub 풀 제한을 ratelimit.Provider 형태로 제공
+type RateLimitProvider struct {
+	Client *Client
+	Auth   DockerHubAuth
+}
+
+// NewRateLimitProvider Docker Hub rate limit Provider를 생성
+func NewRateLimitProvider(client *Client, auth DockerHubAuth) *RateLimitProvider {
+	return &RateLimitProvider{Client: client, Auth: auth}
+}
+
+// Name 레지스트리 이름
+func (p *RateLimitProvider) Name() string {
+	return registry.DockerHubRegistry
+}
+
+// Matches docker.io 및 별칭 호스트를 담당
+func (p *RateLimitProvider) Matches(host string) bool {
+	return registry.IsDockerHub(host)
+}
+
+// Check Docker Hub rate limit을 조회하여 공통 Quota 형식으로 변환
+func (p *RateLimitProvider) Check(ctx context.Context) ([]ratelimit.Quota, error) {
+	rateLimit, err := p.Client.GetRateLimit(ctx, p.Auth)
+	if err != nil {
+		return nil, err
+	}
+	return []ratelimit.Quota{rateLimit.Quota(p.Auth)}, nil
+}
+
+// Quota Docker Hub rate limit 조회 결과를 공통 Quota 형식으로 변환
+func (r *DockerHubRateLimit) Quota(auth DockerHubAuth) ratelimit.Quota {
+	identity := "anonymous"
+	switch {
+	case auth.Username != "":
+		identity = auth.Username
+	case auth.Token != "":
+		identity = "token"
+	}
+
+	return ratelimit.Quota{
+		Registry:  registry.DockerHubRegistry,
+		Resource:  "pulls",
+		Limit:     int64(r.Limit),
+		Remaining: int64(r.Remaining),
+		Unit:      "pulls",
+		Window:    r.Window,
+		Reset:     r.Reset,
+		Source:    r.Source,
+		Identity:  identity,
+		Throttled: r.Limit > 0 && r.Remaining == 0,
+	}
+}
@@ -1,11 +1,14 @@
 package docker
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
 )
 
 // parseRateLimit "200;w=21600" 형식의 문자열에서 숫자 값을 추출
@@ -24,30 +27,38 @@ func parseRateLimit(value string) int {
 
 // GetDockerHubRateLimit Docker Hub의 rate limit 정보를 조회
 func GetDockerHubRateLimit(auth DockerHubAuth) (*DockerHubRateLimit, error) {
-	client := &http.Client{}
+	return NewClient(nil).GetRateLimit(context.Background(), auth)
+}
 
-	// 1. 토큰 획득
-	token, err := GetDockerHubToken(auth)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get Docker Hub token: %v", err)
+// GetRateLimit 클라이언트에 설정된 레지스트리에서 rate limit 정보를 조회
+func (c *Client) GetRateLimit(ctx context.Context, auth DockerHubAuth) (*DockerHubRateLimit, error) {
+	// 레지스트리의 Bearer challenge에 따라 토큰을 발급받아 매니페스트 HEAD 요청
+	// 기본값이 아닌 토큰 엔드포인트를 지정하면 challenge의 realm 대신 사용
+	transport := registry.NewTransport(c.HTTPClient.Transport, registry.StaticCredentials(auth.Credentials()))
+	if c.AuthURL != "" && c.AuthURL != DefaultAuthURL {
+		transport.Realm = c.AuthURL
 	}
+	authClient := *c.HTTPClient
+	authClient.Transport = transport
 
-	// 2. Rate limit 정보 조회
-	rateURL := "https://registry-1.docker.io/v2/ratelimitpreview/test/manifests/latest"
-	req, err := http.NewRequest("HEAD", rateURL, nil)
+	rateURL := c.RegistryURL + "/v2/" + rateLimitRepository + "/manifests/latest"
+	req, err := http.NewRequestWithContext(ctx, "HEAD", rateURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create rate limit request: %v", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 	req.Header.Set("Accept", "application/vnd.docker.distribution.manifest.v2+json")
 
-	resp, err := client.Do(req)
+	resp, err := authClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get rate limit: %v", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, fmt.Errorf("authentication to Docker Hub failed with status %d", resp.StatusCode)
+	}
+
 	// rate limit 정보를 헤더에서 추출
 	rateLimit := &DockerHubRateLimit{}
 
@@ -57,7 +68,8 @@ func GetDockerHubRateLimit(auth DockerHubAuth) (*DockerHubRateLimit, error) {
 		if parts := strings.Split(limitHeader, ";w="); len(parts) > 1 {
 			window, _ = strconv.Atoi(strings.TrimRight(parts[1], "[]"))
 			// window 값을 이용하여 reset time 계산
-			rateLimit.Reset = time.Now().Add(time.Duration(window) * time.Second)
+			rateLimit.Window = time.Duration(window) * time.Second
+			rateLimit.Reset = time.Now().Add(rateLimit.Window)
 		}
 	}
 
@@ -82,22 +94,3 @@ func GetDockerHubRateLimit(auth DockerHubAuth) (*DockerHubRateLimit, error) {
 
 	return rateLimit, nil
 }
-
-// PrintDockerHubRateLimit Docker Hub rate limit 정보를 출력
-func PrintDockerHubRateLimit(rateLimit *DockerHubRateLimit, auth DockerHubAuth) {
-	// 인증 상태에 따른 메시지 준비
-	authStatus := "Anonymous"
-	if auth.Username != "" && auth.Password != "" {
-		authStatus = "Authenticated"
-	}
-
-	fmt.Printf("\nDocker Hub Rate Limits (%s):\n", authStatus)
-	fmt.Printf("================================\n")
-	fmt.Printf("Limit: %d requests\n", rateLimit.Limit)
-	fmt.Printf("Remaining: %d requests\n", rateLimit.Remaining)
-	if !rateLimit.Reset.IsZero() {
-		fmt.Printf("Reset Time: %s\n", rateLimit.Reset.Format("2006-01-02 15:04:05"))
-	}
-	fmt.Printf("Source: %s\n", rateLimit.Source)
-	fmt.Printf("================================\n\n")
-}
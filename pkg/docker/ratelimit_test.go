@@ -0,0 +1,88 @@
+package docker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// newTokenServer 토큰 요청 횟수를 세는 토큰 엔드포인트
+func newTokenServer(t *testing.T, calls *atomic.Int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if got := r.URL.Query().Get("scope"); got != "repository:"+rateLimitRepository+":pull" {
+			t.Errorf("token request scope = %q", got)
+		}
+		w.Write([]byte(`{"token":"test-token"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newRateLimitRegistry challengeRealm을 realm으로 안내하고 토큰이 있으면 rate limit 헤더를 반환하는 레지스트리
+func newRateLimitRegistry(t *testing.T, challengeRealm string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+challengeRealm+`",service="registry.docker.io"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Ratelimit-Limit", "100;w=21600")
+		w.Header().Set("Ratelimit-Remaining", "76;w=21600")
+		w.Header().Set("Docker-Ratelimit-Source", "203.0.113.7")
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetRateLimitUsesConfiguredAuthURL(t *testing.T) {
+	var challengeCalls, configuredCalls atomic.Int32
+	challengeRealm := newTokenServer(t, &challengeCalls)
+	configured := newTokenServer(t, &configuredCalls)
+
+	client := NewClient(http.DefaultClient)
+	client.RegistryURL = newRateLimitRegistry(t, challengeRealm.URL).URL
+	client.AuthURL = configured.URL + "/token"
+
+	rateLimit, err := client.GetRateLimit(t.Context(), DockerHubAuth{})
+	if err != nil {
+		t.Fatalf("GetRateLimit() error = %v", err)
+	}
+	if rateLimit.Limit != 100 || rateLimit.Remaining != 76 || rateLimit.Source != "203.0.113.7" {
+		t.Errorf("GetRateLimit() = %+v, want limit 100, remaining 76", rateLimit)
+	}
+	if configuredCalls.Load() != 1 {
+		t.Errorf("configured auth URL called %d times, want 1", configuredCalls.Load())
+	}
+	if challengeCalls.Load() != 0 {
+		t.Errorf("challenge realm called %d times although --docker-auth-url is set", challengeCalls.Load())
+	}
+}
+
+func TestGetRateLimitDefaultAuthURLFollowsChallenge(t *testing.T) {
+	var challengeCalls atomic.Int32
+	challengeRealm := newTokenServer(t, &challengeCalls)
+
+	// 기본 AuthURL이면 미러 레지스트리가 안내한 realm을 사용
+	client := NewClient(http.DefaultClient)
+	client.RegistryURL = newRateLimitRegistry(t, challengeRealm.URL).URL
+	if client.AuthURL != DefaultAuthURL {
+		t.Fatalf("NewClient() AuthURL = %q, want %q", client.AuthURL, DefaultAuthURL)
+	}
+
+	if _, err := client.GetRateLimit(t.Context(), DockerHubAuth{}); err != nil {
+		t.Fatalf("GetRateLimit() error = %v", err)
+	}
+	if challengeCalls.Load() != 1 {
+		t.Errorf("challenge realm called %d times, want 1", challengeCalls.Load())
+	}
+}
+
+func TestParseRateLimit(t *testing.T) {
+	for value, want := range map[string]int{"100;w=21600": 100, "76": 76, "": 0, "bogus": 0} {
+		if got := parseRateLimit(value); got != want {
+			t.Errorf("parseRateLimit(%q) = %d, want %d", value, got, want)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package docker
 
-import "time"
+import (
+	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
 
 // DockerHubAuth Docker Hub 인증 정보
 type DockerHubAuth struct {
@@ -9,10 +13,25 @@ type DockerHubAuth struct {
 	Token    string
 }
 
+// IsAuthenticated 사용자명/비밀번호 또는 토큰이 설정되었는지 확인
+func (a DockerHubAuth) IsAuthenticated() bool {
+	return (a.Username != "" && a.Password != "") || a.Token != ""
+}
+
+// Credentials 레지스트리 인증에 사용할 인증 정보로 변환 (토큰은 Bearer 토큰으로 그대로 사용)
+func (a DockerHubAuth) Credentials() registry.Credentials {
+	return registry.Credentials{
+		Username:      a.Username,
+		Password:      a.Password,
+		RegistryToken: a.Token,
+	}
+}
+
 // DockerHubRateLimit Docker Hub의 rate limit 정보를 저장하는 구조체
 type DockerHubRateLimit struct {
 	Limit     int
 	Remaining int
 	Source    string
 	Reset     time.Time
+	Window    time.Duration
 }
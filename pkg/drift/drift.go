@@ -0,0 +1,197 @@
+package drift
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// 드리프트 상태
+const (
+	StatusUpToDate = "up-to-date"
+	StatusOutdated = "outdated"
+	StatusPinned   = "pinned"
+	StatusUnknown  = "unknown"
+)
+
+// ImageDrift 워크로드 컨테이너 하나의 실행 digest와 레지스트리 태그 digest 비교 결과
+type ImageDrift struct {
+	Namespace string
+	Workload  string
+	Container string
+	Image     string
+	Status    string
+	// Pods 이 컨테이너를 실행 중인 Pod 수
+	Pods int
+	// OutdatedPods 레지스트리 태그와 다른 digest를 실행 중인 Pod 수
+	OutdatedPods int
+	// RunningDigests 오래된 Pod들이 실행 중인 digest 목록
+	RunningDigests []string
+	// RegistryDigest 레지스트리에서 태그가 현재 가리키는 digest
+	RegistryDigest string
+	// UpdatedAt 레지스트리의 현재 이미지 config에 기록된 생성 시각 (모르면 nil)
+	UpdatedAt *time.Time
+	// OldestStart 오래된 digest를 실행 중인 컨테이너 중 가장 먼저 시작한 시각 (모르면 nil)
+	OldestStart *time.Time
+	// Error 태그 조회에 실패한 경우의 오류 메시지
+	Error string
+}
+
+// Behind 태그가 가리키는 새 이미지가 빌드된 이후 경과 시간 (config의 created 기준, 모르면 0)
+// push 시각이 아니라 빌드 시각이므로 재현 가능한 빌드처럼 created가 고정된 이미지는 실제보다 길게 나올 수 있음
+func (d ImageDrift) Behind(now time.Time) time.Duration {
+	if d.UpdatedAt == nil {
+		return 0
+	}
+	return now.Sub(*d.UpdatedAt)
+}
+
+// resolvedTag 레지스트리에서 조회한 태그 정보 (태그별로 한 번만 조회)
+type resolvedTag struct {
+	digest string
+	// manifests 인덱스와 하위 플랫폼 매니페스트 digest
+	manifests map[string]bool
+	// configs 플랫폼 매니페스트의 config digest (이미지 ID 비교가 필요할 때만 조회)
+	configs map[string]bool
+	created *time.Time
+	err     error
+}
+
+// Checker 레지스트리 태그를 조회하여 실행 중인 이미지의 드리프트를 확인
+type Checker struct {
+	Client *registry.Client
+	// Platform 멀티 아키텍처 이미지의 생성 시각을 읽을 플랫폼
+	Platform registry.Platform
+	tags     map[string]*resolvedTag
+}
+
+// NewChecker 레지스트리 클라이언트로 Checker를 생성
+func NewChecker(client *registry.Client, platform registry.Platform) *Checker {
+	return &Checker{Client: client, Platform: platform, tags: make(map[string]*resolvedTag)}
+}
+
+// Check 실행 중인 컨테이너를 워크로드/컨테이너/이미지별로 묶어 레지스트리 태그와 비교
+func (c *Checker) Check(ctx context.Context, containers []kubernetes.RunningContainer) []ImageDrift {
+	type groupKey struct{ namespace, workload, container, image string }
+	groups := make(map[groupKey]*ImageDrift)
+	var keys []groupKey
+
+	for _, container := range containers {
+		key := groupKey{container.Namespace, container.Workload, container.Container, container.Image}
+		drift, ok := groups[key]
+		if !ok {
+			drift = &ImageDrift{
+				Namespace: container.Namespace,
+				Workload:  container.Workload,
+				Container: container.Container,
+				Image:     container.Image,
+				Status:    StatusUpToDate,
+			}
+			groups[key] = drift
+			keys = append(keys, key)
+		}
+		drift.Pods++
+
+		ref, err := registry.ParseReference(container.Image)
+		if err != nil {
+			drift.Status, drift.Error = StatusUnknown, err.Error()
+			continue
+		}
+		if ref.Digest != "" {
+			drift.Status = StatusPinned
+			continue
+		}
+		if container.Digest() == "" {
+			if drift.Status == StatusUpToDate {
+				drift.Status = StatusUnknown
+			}
+			continue
+		}
+
+		tag := c.resolve(ctx, ref)
+		if tag.err != nil {
+			drift.Status, drift.Error = StatusUnknown, tag.err.Error()
+			continue
+		}
+		drift.RegistryDigest = tag.digest
+		drift.UpdatedAt = tag.created
+		if c.matches(ctx, ref, tag, container) {
+			continue
+		}
+		drift.Status = StatusOutdated
+		drift.OutdatedPods++
+		if start := container.StartedAt; !start.IsZero() && (drift.OldestStart == nil || start.Before(*drift.OldestStart)) {
+			drift.OldestStart = &start
+		}
+		if !slices.Contains(drift.RunningDigests, container.Digest()) {
+			drift.RunningDigests = append(drift.RunningDigests, container.Digest())
+		}
+	}
+
+	drifts := make([]ImageDrift, 0, len(keys))
+	for _, key := range keys {
+		drifts = append(drifts, *groups[key])
+	}
+	sort.Slice(drifts, func(i, j int) bool {
+		if drifts[i].Namespace != drifts[j].Namespace {
+			return drifts[i].Namespace < drifts[j].Namespace
+		}
+		if drifts[i].Workload != drifts[j].Workload {
+			return drifts[i].Workload < drifts[j].Workload
+		}
+		return drifts[i].Container < drifts[j].Container
+	})
+	return drifts
+}
+
+// resolve 태그가 현재 가리키는 매니페스트와 생성 시각을 조회 (결과는 캐시)
+func (c *Checker) resolve(ctx context.Context, ref registry.Reference) *resolvedTag {
+	if tag, ok := c.tags[ref.String()]; ok {
+		return tag
+	}
+	tag := &resolvedTag{manifests: make(map[string]bool)}
+	c.tags[ref.String()] = tag
+
+	manifest, err := c.Client.GetManifest(ctx, ref)
+	if err != nil {
+		tag.err = err
+		return tag
+	}
+	tag.digest = manifest.Digest
+	tag.manifests[manifest.Digest] = true
+	for _, desc := range manifest.Manifests {
+		tag.manifests[desc.Digest] = true
+	}
+
+	// 생성 시각은 부가 정보이므로 조회 실패를 무시
+	if platformManifest, err := c.Client.PlatformManifest(ctx, ref, manifest, c.Platform); err == nil {
+		if config, err := c.Client.GetConfig(ctx, ref, platformManifest); err == nil && config.Created != nil && config.Created.Unix() > 0 {
+			tag.created = config.Created
+		}
+	}
+	return tag
+}
+
+// matches 컨테이너가 실행 중인 digest가 태그의 현재 이미지와 같은지 확인
+func (c *Checker) matches(ctx context.Context, ref registry.Reference, tag *resolvedTag, container kubernetes.RunningContainer) bool {
+	if container.RepoDigest != "" {
+		return tag.manifests[container.RepoDigest]
+	}
+
+	// imageID만 있는 런타임은 플랫폼 매니페스트의 config digest와 비교
+	if tag.configs == nil {
+		tag.configs = make(map[string]bool)
+		for digest := range tag.manifests {
+			manifest, err := c.Client.GetManifest(ctx, ref.WithDigest(digest))
+			if err != nil || manifest.IsIndex() {
+				continue
+			}
+			tag.configs[manifest.Config.Digest] = true
+		}
+	}
+	return tag.configs[container.ConfigDigest]
+}
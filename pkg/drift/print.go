@@ -0,0 +1,87 @@
+package drift
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/units"
+)
+
+// PrintImageDrift 레지스트리 태그보다 뒤처진 워크로드 목록과 요약 출력 (all이면 최신 상태도 포함)
+func PrintImageDrift(drifts []ImageDrift, all bool) {
+	now := time.Now()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "\nImage Drift Report:\n")
+	fmt.Fprintln(w, "=======================================================================")
+	fmt.Fprintln(w, "No.\tNamespace\tWorkload\tContainer\tImage\tStatus\tPods\tRunning\tRegistry\tBehind\tOldest Pod")
+	fmt.Fprintln(w, "-----------------------------------------------------------------------")
+	no := 0
+	for _, drift := range drifts {
+		if !all && drift.Status != StatusOutdated {
+			continue
+		}
+		no++
+		pods := fmt.Sprintf("%d", drift.Pods)
+		if drift.OutdatedPods > 0 {
+			pods = fmt.Sprintf("%d/%d", drift.OutdatedPods, drift.Pods)
+		}
+		running := make([]string, 0, len(drift.RunningDigests))
+		for _, digest := range drift.RunningDigests {
+			running = append(running, shortDigest(digest))
+		}
+		behind := "-"
+		if drift.Status == StatusOutdated && drift.UpdatedAt != nil {
+			behind = units.FormatAge(drift.Behind(now))
+		}
+		oldest := "-"
+		if drift.OldestStart != nil {
+			oldest = units.FormatAge(now.Sub(*drift.OldestStart))
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", no, drift.Namespace, drift.Workload, drift.Container,
+			drift.Image, drift.Status, pods, orDash(strings.Join(running, ",")), orDash(shortDigest(drift.RegistryDigest)), behind, oldest)
+	}
+	fmt.Fprintln(w, "=======================================================================")
+	w.Flush()
+
+	// 요약 정보 출력
+	counts := make(map[string]int)
+	var outdatedPods int
+	for _, drift := range drifts {
+		counts[drift.Status]++
+		outdatedPods += drift.OutdatedPods
+	}
+	fmt.Printf("\nSummary:\n")
+	fmt.Printf("- Containers checked: %d\n", len(drifts))
+	fmt.Printf("- Outdated: %d (%d pods)\n", counts[StatusOutdated], outdatedPods)
+	fmt.Printf("- Up to date: %d\n", counts[StatusUpToDate])
+	fmt.Printf("- Pinned by digest: %d\n", counts[StatusPinned])
+	fmt.Printf("- Unknown: %d\n", counts[StatusUnknown])
+	fmt.Printf("- Note: Behind is the time since the image the tag now points to was built (config created, not push time); Oldest Pod is how long the oldest outdated container has been running\n")
+
+	for _, drift := range drifts {
+		if drift.Error != "" {
+			fmt.Printf("  - %s/%s (%s): %s\n", drift.Namespace, drift.Workload, drift.Image, drift.Error)
+		}
+	}
+}
+
+// shortDigest digest를 "sha256:" 이후 12자리로 줄여서 표시
+func shortDigest(digest string) string {
+	algorithm, hex, ok := strings.Cut(digest, ":")
+	if !ok || len(hex) <= 12 {
+		return digest
+	}
+	return algorithm + ":" + hex[:12]
+}
+
+// orDash 빈 문자열을 "-"로 표시
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
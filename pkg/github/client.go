@@ -0,0 +1,30 @@
+package github
+
+import (
+	"net/http"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/httpclient"
+)
+
+// DefaultAPIURL GitHub REST API 기본 엔드포인트
+const DefaultAPIURL = "https://api.github.com"
+
+// apiVersion 요청에 지정하는 GitHub REST API 버전
+const apiVersion = "2022-11-28"
+
+// Client GitHub REST API 클라이언트
+type Client struct {
+	HTTPClient *http.Client
+	BaseURL    string
+}
+
+// NewClient 기본 GitHub API 엔드포인트를 사용하는 클라이언트를 생성 (httpClient가 nil이면 기본 클라이언트 사용)
+func NewClient(httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = httpclient.Default()
+	}
+	return &Client{
+		HTTPClient: httpClient,
+		BaseURL:    DefaultAPIURL,
+	}
+}
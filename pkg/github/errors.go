@@ -0,0 +1,65 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError GitHub API가 반환한 오류 응답
+type APIError struct {
+	StatusCode       int
+	Message          string
+	DocumentationURL string
+	// RateLimited 403/429 응답이 rate limit 초과로 인한 것인지 여부
+	RateLimited bool
+}
+
+// Error 상태 코드와 메시지를 포함한 오류 문자열
+func (e *APIError) Error() string {
+	switch {
+	case e.StatusCode == http.StatusUnauthorized:
+		return fmt.Sprintf("GitHub authentication failed (status %d): %s; check that the token is valid and not expired", e.StatusCode, e.Message)
+	case e.RateLimited:
+		return fmt.Sprintf("GitHub API rate limit exceeded (status %d): %s", e.StatusCode, e.Message)
+	default:
+		return fmt.Sprintf("API request failed with status %d: %s", e.StatusCode, e.Message)
+	}
+}
+
+// IsUnauthorized 오류가 잘못되었거나 만료된 토큰으로 인한 것인지 확인
+func IsUnauthorized(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized
+}
+
+// IsRateLimited 오류가 rate limit 초과로 인한 것인지 확인
+func IsRateLimited(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.RateLimited
+}
+
+// newAPIError 응답 상태와 본문으로 APIError를 생성
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+
+	var errResp struct {
+		Message          string `json:"message"`
+		DocumentationURL string `json:"documentation_url"`
+	}
+	if err := json.Unmarshal(body, &errResp); err == nil && errResp.Message != "" {
+		apiErr.Message = errResp.Message
+		apiErr.DocumentationURL = errResp.DocumentationURL
+	} else {
+		apiErr.Message = strings.TrimSpace(string(body))
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0") {
+		apiErr.RateLimited = true
+	}
+
+	return apiErr
+}
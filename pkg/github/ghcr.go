@@ -0,0 +1,129 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/httpclient"
+	"github.com/suslmk-lee/zim-image-management/pkg/ratelimit"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// DefaultGHCRURL GitHub Container Registry 엔드포인트
+const DefaultGHCRURL = "https://ghcr.io"
+
+// GHCRClient GitHub Container Registry (ghcr.io) 클라이언트
+type GHCRClient struct {
+	HTTPClient  *http.Client
+	RegistryURL string
+	// Username 토큰과 함께 사용할 GitHub 사용자명 (ghcr.io는 임의의 값도 허용)
+	Username string
+	// Token ghcr.io 인증에 사용할 GitHub 토큰 (비어 있으면 익명)
+	Token string
+
+	authOnce   sync.Once
+	authClient *http.Client
+}
+
+// GHCRProbeResult ghcr.io 매니페스트 조회 결과와 응답에 포함된 제한 정보
+type GHCRProbeResult struct {
+	Repository    string
+	Reference     string
+	Authenticated bool
+	StatusCode    int
+	Digest        string
+	Throttled     bool
+	RetryAfter    time.Duration
+	// RateLimitHeaders 응답에 포함된 rate limit 관련 헤더 (ghcr.io는 보통 제공하지 않음)
+	RateLimitHeaders map[string]string
+	Errors           []registry.Error
+}
+
+// NewGHCRClient ghcr.io 클라이언트를 생성 (httpClient가 nil이면 기본 클라이언트 사용)
+func NewGHCRClient(httpClient *http.Client, username, token string) *GHCRClient {
+	if httpClient == nil {
+		httpClient = httpclient.Default()
+	}
+	if token != "" && username == "" {
+		username = "zim"
+	}
+	return &GHCRClient{
+		HTTPClient:  httpClient,
+		RegistryURL: DefaultGHCRURL,
+		Username:    username,
+		Token:       token,
+	}
+}
+
+// ProbeManifest ghcr.io 토큰 엔드포인트에서 인증 후 매니페스트를 조회하여 제한 및 오류 정보를 수집
+func (c *GHCRClient) ProbeManifest(ctx context.Context, repository, reference string) (*GHCRProbeResult, error) {
+	authClient := c.registryClient()
+
+	result := &GHCRProbeResult{
+		Repository:       repository,
+		Reference:        reference,
+		Authenticated:    c.Token != "",
+		RateLimitHeaders: make(map[string]string),
+	}
+
+	manifestURL := fmt.Sprintf("%s/v2/%s/manifests/%s", c.RegistryURL, repository, reference)
+	resp, err := c.do(ctx, authClient, "HEAD", manifestURL)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	// HEAD 응답에는 본문이 없으므로 실패 시 GET으로 오류 내용을 다시 조회
+	if resp.StatusCode >= 300 {
+		resp, err = c.do(ctx, authClient, "GET", manifestURL)
+		if err != nil {
+			return nil, err
+		}
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+		resp.Body.Close()
+		result.Errors = registry.ParseErrorResponse(body)
+	}
+
+	result.StatusCode = resp.StatusCode
+	result.Digest = resp.Header.Get("Docker-Content-Digest")
+	result.Throttled = resp.StatusCode == http.StatusTooManyRequests
+
+	for name, values := range resp.Header {
+		lower := strings.ToLower(name)
+		if len(values) > 0 && (strings.Contains(lower, "ratelimit") || lower == "retry-after") {
+			result.RateLimitHeaders[name] = strings.Join(values, ", ")
+		}
+	}
+	result.RetryAfter = ratelimit.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+
+	return result, nil
+}
+
+// registryClient 인증 클라이언트를 처음 사용할 때 한 번만 생성 (challenge와 토큰 캐시를 조회마다 재사용)
+func (c *GHCRClient) registryClient() *http.Client {
+	c.authOnce.Do(func() {
+		creds := registry.Credentials{Username: c.Username, Password: c.Token}
+		c.authClient = registry.NewAuthClient(c.HTTPClient, registry.StaticCredentials(creds))
+	})
+	return c.authClient
+}
+
+// do 매니페스트 미디어 타입을 허용하는 요청을 전송
+func (c *GHCRClient) do(ctx context.Context, client *http.Client, method, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create manifest request: %v", err)
+	}
+	req.Header.Set("Accept", registry.ManifestAccept)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ghcr.io manifest: %v", err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,146 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/ratelimit"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// apiRegistryName 공통 결과 표에 표시할 GitHub API 이름
+const apiRegistryName = "api.github.com"
+
+// RateLimitProvider GitHub REST API의 리소스별 rate limit을 ratelimit.Provider 형태로 제공
+type RateLimitProvider struct {
+	Client      *Client
+	Token       string
+	TokenSource string
+}
+
+// Name 제공자 이름
+func (p *RateLimitProvider) Name() string {
+	return apiRegistryName
+}
+
+// Matches GitHub API는 이미지 레지스트리가 아니므로 명시적으로 추가된 경우에만 조회
+func (p *RateLimitProvider) Matches(string) bool {
+	return false
+}
+
+// Check 모든 리소스의 rate limit을 조회하여 공통 Quota 형식으로 변환
+func (p *RateLimitProvider) Check(ctx context.Context) ([]ratelimit.Quota, error) {
+	limits, err := p.Client.GetRateLimits(ctx, p.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := "anonymous"
+	if limits.Authenticated {
+		identity = limits.TokenType
+		if p.TokenSource != "" {
+			identity += " (" + p.TokenSource + ")"
+		}
+	}
+
+	var quotas []ratelimit.Quota
+	for _, name := range limits.ResourceNames() {
+		limit := limits.Resources[name]
+		quotas = append(quotas, limit.Quota(name, identity))
+	}
+	return quotas, nil
+}
+
+// Quota 리소스 하나의 rate limit을 공통 Quota 형식으로 변환
+func (r RateLimit) Quota(resource, identity string) ratelimit.Quota {
+	return ratelimit.Quota{
+		Registry:  apiRegistryName,
+		Resource:  resource,
+		Limit:     int64(r.Limit),
+		Remaining: int64(r.Remaining),
+		Unit:      "requests",
+		Reset:     r.GetResetTime(),
+		Identity:  identity,
+		Throttled: r.Limit > 0 && r.Remaining == 0,
+	}
+}
+
+// GHCRProvider ghcr.io 매니페스트 조회 결과를 ratelimit.Provider 형태로 제공
+type GHCRProvider struct {
+	Client *GHCRClient
+	Images []registry.Reference
+}
+
+// Name 레지스트리 이름
+func (p *GHCRProvider) Name() string {
+	return "ghcr.io"
+}
+
+// Matches ghcr.io 호스트를 담당
+func (p *GHCRProvider) Matches(host string) bool {
+	return registry.NormalizeRegistry(host) == "ghcr.io"
+}
+
+// Check 각 이미지의 매니페스트를 조회하여 throttling 및 오류 정보를 Quota로 변환 (ghcr.io는 풀 한도를 공개하지 않음)
+// 조회에 실패한 이미지는 오류를 Note에 기록하고 나머지 이미지를 계속 조회하며, 모든 이미지가 실패한 경우에만 오류 반환
+func (p *GHCRProvider) Check(ctx context.Context) ([]ratelimit.Quota, error) {
+	if len(p.Images) == 0 {
+		return nil, fmt.Errorf("no ghcr.io images to probe")
+	}
+
+	identity := "anonymous"
+	if p.Client.Token != "" {
+		identity = p.Client.Username
+	}
+
+	var quotas []ratelimit.Quota
+	var firstErr error
+	failed := 0
+	for _, image := range p.Images {
+		quota := ratelimit.Quota{
+			Registry: "ghcr.io",
+			Resource: image.Repository,
+			Unit:     "pulls",
+			Identity: identity,
+		}
+		result, err := p.Client.ProbeManifest(ctx, image.Repository, image.Identifier())
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			failed++
+			quota.Note = "error: " + err.Error()
+			quotas = append(quotas, quota)
+			continue
+		}
+
+		quota.Throttled = result.Throttled
+		if result.RetryAfter > 0 {
+			quota.Reset = time.Now().Add(result.RetryAfter)
+		}
+
+		var notes []string
+		notes = append(notes, fmt.Sprintf("HTTP %d", result.StatusCode))
+		if len(result.Errors) > 0 {
+			notes = append(notes, registry.FormatErrors(result.Errors))
+		}
+		var headerNames []string
+		for name := range result.RateLimitHeaders {
+			headerNames = append(headerNames, name)
+		}
+		sort.Strings(headerNames)
+		for _, name := range headerNames {
+			notes = append(notes, name+"="+result.RateLimitHeaders[name])
+		}
+		quota.Note = strings.Join(notes, "; ")
+
+		quotas = append(quotas, quota)
+	}
+	if failed == len(p.Images) {
+		return nil, firstErr
+	}
+	return quotas, nil
+}
@@ -1,52 +1,77 @@
 package github
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 )
 
-// GetDockerRateLimit GitHub Container Registry의 rate limit 정보를 조회
+// GetDockerRateLimit GitHub REST API의 core rate limit 정보를 조회 (ghcr.io 풀 제한과는 무관, GHCRClient 참고)
 func GetDockerRateLimit(token string) (*RateLimit, error) {
+	return NewClient(nil).GetRateLimit(context.Background(), token)
+}
+
+// GetRateLimit 클라이언트에 설정된 API 엔드포인트에서 core rate limit 정보를 조회
+func (c *Client) GetRateLimit(ctx context.Context, token string) (*RateLimit, error) {
 	if token == "" {
 		return nil, fmt.Errorf("GitHub token is required")
 	}
 
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", "https://api.github.com/rate_limit", nil)
+	limits, err := c.GetRateLimits(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	core, ok := limits.Resources["core"]
+	if !ok {
+		return nil, fmt.Errorf("rate limit response contains no core resource")
+	}
+	return &core, nil
+}
+
+// GetRateLimits /rate_limit 응답의 모든 리소스별 rate limit을 조회 (token이 비어 있으면 익명 조회)
+func (c *Client) GetRateLimits(ctx context.Context, token string) (*RateLimits, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+"/rate_limit", nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %v", err)
 	}
 
-	req.Header.Add("Authorization", "Bearer "+token)
-	req.Header.Add("Accept", "application/vnd.github.v3+json")
+	if token != "" {
+		req.Header.Add("Authorization", "Bearer "+token)
+	}
+	req.Header.Add("Accept", "application/vnd.github+json")
+	req.Header.Add("X-GitHub-Api-Version", apiVersion)
 
-	resp, err := client.Do(req)
+	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %v", err)
 	}
 	defer resp.Body.Close()
 
-	var rateLimit struct {
-		Resources struct {
-			Core RateLimit `json:"core"`
-		} `json:"resources"`
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", err)
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&rateLimit); err != nil {
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, body)
+	}
+
+	var rateLimits RateLimits
+	if err := json.Unmarshal(body, &rateLimits); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %v", err)
 	}
 
-	return &rateLimit.Resources.Core, nil
-}
+	rateLimits.Authenticated = token != ""
+	rateLimits.TokenType = tokenType(token)
+	if scopes := resp.Header.Get("X-OAuth-Scopes"); scopes != "" {
+		for _, scope := range strings.Split(scopes, ",") {
+			rateLimits.Scopes = append(rateLimits.Scopes, strings.TrimSpace(scope))
+		}
+	}
 
-// PrintGitHubRateLimit GitHub Container Registry rate limit 정보를 출력
-func PrintGitHubRateLimit(rateLimit *RateLimit) {
-	fmt.Printf("\nGitHub Docker Rate Limits:\n")
-	fmt.Printf("================================\n")
-	fmt.Printf("Limit: %d\n", rateLimit.Limit)
-	fmt.Printf("Remaining: %d\n", rateLimit.Remaining)
-	fmt.Printf("Used: %d\n", rateLimit.Used)
-	fmt.Printf("Reset Time: %s\n", rateLimit.GetResetTime().Format("2006-01-02 15:04:05"))
-	fmt.Printf("================================\n\n")
+	return &rateLimits, nil
 }
@@ -0,0 +1,42 @@
+package github
+
+import (
+	"os"
+	"strings"
+)
+
+// tokenEnvVars 토큰을 찾을 환경 변수 (우선순위 순)
+var tokenEnvVars = []string{"GITHUB_TOKEN", "GH_TOKEN"}
+
+// ResolveToken 플래그 값이 비어 있으면 GITHUB_TOKEN, GH_TOKEN 환경 변수에서 토큰을 찾아 출처와 함께 반환
+func ResolveToken(flagValue string) (token, source string) {
+	if flagValue != "" {
+		return flagValue, "--github-token"
+	}
+	for _, name := range tokenEnvVars {
+		if value := strings.TrimSpace(os.Getenv(name)); value != "" {
+			return value, name
+		}
+	}
+	return "", ""
+}
+
+// tokenType 토큰 접두사로 토큰 종류를 판별
+func tokenType(token string) string {
+	switch {
+	case token == "":
+		return ""
+	case strings.HasPrefix(token, "ghp_"):
+		return "personal access token (classic)"
+	case strings.HasPrefix(token, "github_pat_"):
+		return "fine-grained personal access token"
+	case strings.HasPrefix(token, "gho_"):
+		return "OAuth access token"
+	case strings.HasPrefix(token, "ghu_"):
+		return "GitHub App user access token"
+	case strings.HasPrefix(token, "ghs_"):
+		return "GitHub App installation token"
+	default:
+		return "unknown"
+	}
+}
@@ -1,8 +1,11 @@
 package github
 
-import "time"
+import (
+	"sort"
+	"time"
+)
 
-// RateLimit GitHub Container Registry의 rate limit 정보
+// RateLimit GitHub REST API의 rate limit 정보
 type RateLimit struct {
 	Limit     int   `json:"limit"`
 	Remaining int   `json:"remaining"`
@@ -14,3 +17,31 @@ type RateLimit struct {
 func (r *RateLimit) GetResetTime() time.Time {
 	return time.Unix(r.Reset, 0)
 }
+
+// RateLimits /rate_limit 응답의 리소스별 rate limit 정보 (core, search, graphql 등)
+type RateLimits struct {
+	Resources map[string]RateLimit `json:"resources"`
+	Rate      RateLimit            `json:"rate"`
+
+	// Authenticated 토큰을 사용해 조회했는지 여부
+	Authenticated bool `json:"-"`
+	// TokenType 토큰 접두사로 판별한 토큰 종류
+	TokenType string `json:"-"`
+	// Scopes classic 토큰의 X-OAuth-Scopes 헤더 값
+	Scopes []string `json:"-"`
+}
+
+// ResourceNames core를 맨 앞에 두고 나머지는 이름순으로 정렬한 리소스 이름 목록
+func (r *RateLimits) ResourceNames() []string {
+	var names []string
+	for name := range r.Resources {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if names[i] == "core" || names[j] == "core" {
+			return names[i] == "core"
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
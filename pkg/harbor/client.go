@@ -0,0 +1,86 @@
+package harbor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/httpclient"
+)
+
+// Client Harbor API v2.0 클라이언트
+type Client struct {
+	HTTPClient *http.Client
+	BaseURL    string
+	Username   string
+	Password   string
+}
+
+// ProjectSummary 프로젝트 요약 정보 중 quota 관련 필드
+type ProjectSummary struct {
+	RepoCount int64 `json:"repo_count"`
+	Quota     *struct {
+		Hard ResourceList `json:"hard"`
+		Used ResourceList `json:"used"`
+	} `json:"quota"`
+}
+
+// ResourceList Harbor quota 리소스 값 (storage는 바이트 단위, -1은 무제한)
+type ResourceList struct {
+	Storage int64 `json:"storage"`
+}
+
+// NewClient Harbor 클라이언트를 생성 (httpClient가 nil이면 기본 클라이언트 사용)
+func NewClient(httpClient *http.Client, baseURL, username, password string) *Client {
+	if httpClient == nil {
+		httpClient = httpclient.Default()
+	}
+	if !strings.Contains(baseURL, "://") {
+		baseURL = "https://" + baseURL
+	}
+	return &Client{
+		HTTPClient: httpClient,
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		Username:   username,
+		Password:   password,
+	}
+}
+
+// GetProjectSummary 프로젝트의 저장소 수와 storage quota 사용량을 조회
+func (c *Client) GetProjectSummary(ctx context.Context, project string) (*ProjectSummary, error) {
+	summaryURL := fmt.Sprintf("%s/api/v2.0/projects/%s/summary", c.BaseURL, url.PathEscape(project))
+	req, err := http.NewRequestWithContext(ctx, "GET", summaryURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create project summary request: %v", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	// 프로젝트 이름을 ID가 아닌 이름으로 해석하도록 지정
+	req.Header.Set("X-Is-Resource-Name", "true")
+	if c.Username != "" || c.Password != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project summary for %s: %v", project, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("project summary request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var summary ProjectSummary
+	if err := json.Unmarshal(body, &summary); err != nil {
+		return nil, fmt.Errorf("failed to decode project summary: %v", err)
+	}
+	return &summary, nil
+}
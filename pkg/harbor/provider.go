@@ -0,0 +1,91 @@
+package harbor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/ratelimit"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+	"github.com/suslmk-lee/zim-image-management/pkg/units"
+)
+
+// QuotaProvider Harbor 프로젝트 storage quota와 사용량을 ratelimit.Provider 형태로 제공
+type QuotaProvider struct {
+	Client   *Client
+	Projects []string
+}
+
+// NewQuotaProvider 사용 중인 이미지의 첫 번째 경로(프로젝트 이름)를 기준으로 Provider를 생성
+func NewQuotaProvider(client *Client, images []registry.Reference) *QuotaProvider {
+	seen := make(map[string]bool)
+	var projects []string
+	for _, image := range images {
+		project := strings.SplitN(image.Repository, "/", 2)[0]
+		if !seen[project] {
+			seen[project] = true
+			projects = append(projects, project)
+		}
+	}
+	sort.Strings(projects)
+	return &QuotaProvider{Client: client, Projects: projects}
+}
+
+// Name 레지스트리 이름
+func (p *QuotaProvider) Name() string {
+	return p.host()
+}
+
+// Matches 클라이언트에 설정된 Harbor 호스트를 담당
+func (p *QuotaProvider) Matches(host string) bool {
+	return registry.NormalizeRegistry(host) == p.host()
+}
+
+// host 클라이언트 BaseURL의 호스트 이름
+func (p *QuotaProvider) host() string {
+	return registry.NormalizeRegistry(p.Client.BaseURL)
+}
+
+// Check 프로젝트별 storage quota를 조회하여 바이트 단위 Quota로 변환
+func (p *QuotaProvider) Check(ctx context.Context) ([]ratelimit.Quota, error) {
+	if len(p.Projects) == 0 {
+		return nil, fmt.Errorf("no %s projects in use", p.host())
+	}
+
+	identity := "anonymous"
+	if p.Client.Username != "" {
+		identity = p.Client.Username
+	}
+
+	var quotas []ratelimit.Quota
+	for _, project := range p.Projects {
+		quota := ratelimit.Quota{
+			Registry: p.host(),
+			Resource: "project/" + project + " storage",
+			Unit:     "bytes",
+			Identity: identity,
+		}
+
+		summary, err := p.Client.GetProjectSummary(ctx, project)
+		if err != nil {
+			quota.Note = fmt.Sprintf("error: %v", err)
+			quotas = append(quotas, quota)
+			continue
+		}
+
+		switch {
+		case summary.Quota == nil:
+			quota.Note = "quota not visible to this account"
+		case summary.Quota.Hard.Storage < 0:
+			quota.Note = fmt.Sprintf("unlimited (used %s)", units.FormatBytes(summary.Quota.Used.Storage))
+		default:
+			quota.Limit = summary.Quota.Hard.Storage
+			quota.Remaining = summary.Quota.Hard.Storage - summary.Quota.Used.Storage
+			quota.Throttled = quota.Remaining <= 0
+			quota.Note = fmt.Sprintf("%d repositories", summary.RepoCount)
+		}
+		quotas = append(quotas, quota)
+	}
+	return quotas, nil
+}
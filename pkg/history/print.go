@@ -0,0 +1,113 @@
+package history
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/units"
+)
+
+// PrintHistory 저장소 세그먼트, 기간 내 레지스트리별 풀 횟수, rate limit 추이를 출력
+func PrintHistory(dir string, segments []Segment, pulls []PullRecord, samples []RateLimitSample, since int) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "\nHistory Store (%s):\n", dir)
+	fmt.Fprintln(w, "=======================================================================")
+	fmt.Fprintln(w, "Kind\tSegments\tOldest\tNewest\tSize")
+	fmt.Fprintln(w, "-----------------------------------------------------------------------")
+	var totalSize int64
+	for _, kind := range []string{KindPulls, KindRateLimits} {
+		var count int
+		var size int64
+		var oldest, newest time.Time
+		for _, segment := range segments {
+			if segment.Kind != kind {
+				continue
+			}
+			count++
+			size += segment.Size
+			if oldest.IsZero() || segment.Day.Before(oldest) {
+				oldest = segment.Day
+			}
+			if segment.Day.After(newest) {
+				newest = segment.Day
+			}
+		}
+		totalSize += size
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", kind, count, formatDay(oldest), formatDay(newest), units.FormatBytes(size))
+	}
+	fmt.Fprintln(w, "=======================================================================")
+	w.Flush()
+
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "\nPull History (Last %d hours):\n", since)
+	fmt.Fprintln(w, "Registry\tPulls\tImages\tNodes\tFirst Pull\tLast Pull")
+	fmt.Fprintln(w, "-----------------------------------------------------------------------")
+	for _, r := range SummarizePulls(pulls) {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\t%s\n", r.Registry, r.Pulls, r.Images, r.Nodes, formatTime(r.First), formatTime(r.Last))
+	}
+	w.Flush()
+
+	trends := SummarizeRateLimits(samples)
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "\nRate Limit History (Last %d hours):\n", since)
+	fmt.Fprintln(w, "Registry\tResource\tIdentity\tSamples\tLimit\tMin Remaining\tLast Remaining\tThrottled\tErrors\tLast Sample")
+	fmt.Fprintln(w, "-----------------------------------------------------------------------")
+	for _, t := range trends {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\t%s\t%d\t%d\t%s\n", t.Registry, orDash(t.Resource), orDash(t.Identity), t.Samples,
+			formatAmount(t, t.Limit), formatAmount(t, t.MinRemaining), formatAmount(t, t.LastRemaining),
+			t.Throttled, t.Errors, formatTime(t.Last))
+	}
+	w.Flush()
+
+	// 요약 정보 출력
+	var throttled int
+	for _, t := range trends {
+		throttled += t.Throttled
+	}
+	fmt.Printf("\nSummary:\n")
+	fmt.Printf("- Stored pull events in range: %d\n", len(pulls))
+	if len(pulls) > 0 {
+		fmt.Printf("- Pulls recorded from %s to %s\n", formatTime(pulls[0].Time), formatTime(pulls[len(pulls)-1].Time))
+	}
+	fmt.Printf("- Rate limit samples in range: %d (%d throttled)\n", len(samples), throttled)
+	fmt.Printf("- Store size: %s in %d segments\n", units.FormatBytes(totalSize), len(segments))
+	fmt.Println()
+}
+
+// formatAmount 단위에 맞게 제한 값을 표시 (제한 정보가 없으면 "-")
+func formatAmount(t RateLimitTrend, value int64) string {
+	if t.Limit <= 0 || value < 0 {
+		return "-"
+	}
+	if t.Unit == "bytes" {
+		return units.FormatBytes(value)
+	}
+	return strconv.FormatInt(value, 10)
+}
+
+// formatDay 세그먼트 날짜를 표시 (없으면 "-")
+func formatDay(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(segmentLayout)
+}
+
+// formatTime 시각을 로컬 시간으로 표시 (없으면 "-")
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+// orDash 빈 문자열을 "-"로 표시
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
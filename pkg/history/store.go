@@ -0,0 +1,424 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/ratelimit"
+)
+
+// 세그먼트 종류 (저장소 아래 디렉터리 이름)
+const (
+	KindPulls      = "pulls"
+	KindRateLimits = "ratelimits"
+)
+
+// segmentLayout 하루 단위 세그먼트 파일 이름 (UTC 날짜)
+const segmentLayout = "2006-01-02"
+
+// crioTimeLayout 합성 로그 라인의 CRI-O time 필드 형식 (kubernetes.ParsePullEvent와 같은 형식)
+const crioTimeLayout = "2006-01-02 15:04:05.999999999-07:00"
+
+// PullRecord 저장된 풀 이벤트 하나
+type PullRecord struct {
+	Time      time.Time `json:"time"`
+	Node      string    `json:"node,omitempty"`
+	Reference string    `json:"reference"`
+	// ID CRI-O 요청 ID (로그에 없으면 빈 문자열)
+	ID string `json:"id,omitempty"`
+}
+
+// Key 중복 제거에 사용하는 키 (같은 노드, 같은 시각, 같은 참조, 같은 요청 ID는 같은 풀)
+// journal 시각은 초 단위이므로 요청 ID가 있으면 같은 초에 반복된 풀도 구분됨
+func (r PullRecord) Key() string {
+	return r.Node + "|" + r.Time.UTC().Format(time.RFC3339Nano) + "|" + r.Reference + "|" + r.ID
+}
+
+// LogLine journalctl 출력과 같은 형식의 로그 라인 (기존 풀 이벤트 집계 함수에 그대로 전달 가능)
+func (r PullRecord) LogLine() string {
+	prefix := r.Time.Local().Format(time.Stamp)
+	if r.Node != "" {
+		prefix += " " + r.Node
+	}
+	line := fmt.Sprintf(`%s crio[0]: time="%s" level=info msg="Pulled image: %s"`, prefix, r.Time.Format(crioTimeLayout), r.Reference)
+	if r.ID != "" {
+		line += " id=" + r.ID
+	}
+	return line + " source=history"
+}
+
+// RateLimitSample 특정 시각에 조회한 rate limit 하나
+type RateLimitSample struct {
+	Time time.Time `json:"time"`
+	ratelimit.Record
+}
+
+// Segment 세그먼트 파일 하나의 정보
+type Segment struct {
+	Kind string
+	Day  time.Time
+	Path string
+	Size int64
+}
+
+// Store 풀 이벤트와 rate limit 샘플을 날짜별 JSONL 세그먼트로 보관하는 저장소
+// 같은 프로세스 안의 쓰기는 직렬화하고, 여러 프로세스가 같은 풀을 기록해도 읽을 때 중복을 제거
+type Store struct {
+	Dir string
+	// Retention 세그먼트 보관 기간 (0이면 삭제하지 않음)
+	Retention time.Duration
+	// Warnf 세그먼트에서 해석할 수 없는 라인을 건너뛸 때 호출 (nil이면 알리지 않음)
+	Warnf func(format string, args ...any)
+
+	mu sync.Mutex
+}
+
+// Open 저장소 디렉터리를 준비 (없으면 생성)
+func Open(dir string, retention time.Duration) (*Store, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("history directory is empty")
+	}
+	if retention < 0 {
+		return nil, fmt.Errorf("invalid retention %s", retention)
+	}
+	for _, kind := range []string{KindPulls, KindRateLimits} {
+		if err := os.MkdirAll(filepath.Join(dir, kind), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create history directory: %v", err)
+		}
+	}
+	return &Store{Dir: dir, Retention: retention}, nil
+}
+
+// ParsePullRecord 풀 이벤트 로그 라인을 저장용 레코드로 변환 (호스트 이름이 없으면 defaultNode, 시각을 모르면 false)
+func ParsePullRecord(line, defaultNode string) (PullRecord, bool) {
+	event, ok := kubernetes.ParsePullEvent(line)
+	if !ok || event.Time.IsZero() {
+		return PullRecord{}, false
+	}
+	node := event.Node
+	if node == "" {
+		node = defaultNode
+	}
+	return PullRecord{Time: event.Time, Node: node, Reference: event.Reference.String(), ID: event.ID}, true
+}
+
+// AddPullEvents 풀 이벤트 로그 라인 중 아직 저장되지 않은 것만 추가하고 추가한 개수를 반환
+func (s *Store) AddPullEvents(pullEvents []string, defaultNode string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	byDay := make(map[string][]PullRecord)
+	for _, line := range pullEvents {
+		record, ok := ParsePullRecord(line, defaultNode)
+		if !ok {
+			continue
+		}
+		day := record.Time.UTC().Format(segmentLayout)
+		byDay[day] = append(byDay[day], record)
+	}
+
+	added := 0
+	for _, day := range sortedDays(byDay) {
+		path := s.segmentPath(KindPulls, day)
+		existing, err := s.readPullRecords(path)
+		if err != nil {
+			return added, err
+		}
+		seen := make(map[string]bool, len(existing))
+		for _, record := range existing {
+			seen[record.Key()] = true
+		}
+		var records []any
+		for _, record := range byDay[day] {
+			if seen[record.Key()] {
+				continue
+			}
+			seen[record.Key()] = true
+			records = append(records, record)
+		}
+		if err := appendRecords(path, records); err != nil {
+			return added, err
+		}
+		added += len(records)
+	}
+	return added, nil
+}
+
+// AddRateLimits 조회 시각 t의 rate limit 결과를 샘플로 추가 (실패한 Provider는 오류 샘플로 기록)
+func (s *Store) AddRateLimits(t time.Time, results []ratelimit.Result) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var records []any
+	for _, record := range ratelimit.Records(results) {
+		records = append(records, RateLimitSample{Time: t.UTC(), Record: record})
+	}
+	return appendRecords(s.segmentPath(KindRateLimits, t.UTC().Format(segmentLayout)), records)
+}
+
+// Pulls from 이후 to 이전의 풀 이벤트를 시각 순으로 조회 (중복은 하나만 반환)
+func (s *Store) Pulls(from, to time.Time) ([]PullRecord, error) {
+	paths, err := s.segmentsBetween(KindPulls, from, to)
+	if err != nil {
+		return nil, err
+	}
+	var records []PullRecord
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		segment, err := s.readPullRecords(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range segment {
+			if !inRange(record.Time, from, to) || seen[record.Key()] {
+				continue
+			}
+			seen[record.Key()] = true
+			records = append(records, record)
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Time.Before(records[j].Time) })
+	return records, nil
+}
+
+// RateLimits from 이후 to 이전의 rate limit 샘플을 시각 순으로 조회
+func (s *Store) RateLimits(from, to time.Time) ([]RateLimitSample, error) {
+	paths, err := s.segmentsBetween(KindRateLimits, from, to)
+	if err != nil {
+		return nil, err
+	}
+	var samples []RateLimitSample
+	for _, path := range paths {
+		err := s.readLines(path, func(data []byte) error {
+			var sample RateLimitSample
+			if err := json.Unmarshal(data, &sample); err != nil {
+				return err
+			}
+			if inRange(sample.Time, from, to) {
+				samples = append(samples, sample)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(samples, func(i, j int) bool { return samples[i].Time.Before(samples[j].Time) })
+	return samples, nil
+}
+
+// Segments 저장된 세그먼트 목록 (종류, 날짜 순)
+func (s *Store) Segments() ([]Segment, error) {
+	var segments []Segment
+	for _, kind := range []string{KindPulls, KindRateLimits} {
+		entries, err := os.ReadDir(filepath.Join(s.Dir, kind))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read history directory: %v", err)
+		}
+		for _, entry := range entries {
+			name, ok := strings.CutSuffix(entry.Name(), ".jsonl")
+			if !ok || entry.IsDir() {
+				continue
+			}
+			day, err := time.Parse(segmentLayout, name)
+			if err != nil {
+				continue
+			}
+			info, err := entry.Info()
+			if err != nil {
+				return nil, fmt.Errorf("failed to stat %s: %v", entry.Name(), err)
+			}
+			segments = append(segments, Segment{
+				Kind: kind,
+				Day:  day,
+				Path: filepath.Join(s.Dir, kind, entry.Name()),
+				Size: info.Size(),
+			})
+		}
+	}
+	return segments, nil
+}
+
+// Prune 보관 기간이 지난 세그먼트를 삭제하고 삭제한 세그먼트를 반환 (Retention이 0이면 아무것도 삭제하지 않음)
+func (s *Store) Prune(now time.Time) ([]Segment, error) {
+	if s.Retention <= 0 {
+		return nil, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	segments, err := s.Segments()
+	if err != nil {
+		return nil, err
+	}
+	cutoff := now.Add(-s.Retention)
+	var pruned []Segment
+	for _, segment := range segments {
+		// 세그먼트의 마지막 시각까지 보관 기간이 지나야 삭제
+		if !segment.Day.AddDate(0, 0, 1).Before(cutoff) {
+			continue
+		}
+		if err := os.Remove(segment.Path); err != nil {
+			return pruned, fmt.Errorf("failed to remove %s: %v", segment.Path, err)
+		}
+		pruned = append(pruned, segment)
+	}
+	return pruned, nil
+}
+
+// MergePullEvents journal 풀 이벤트에 journal에 없는 저장된 풀 이벤트를 합성 로그 라인으로 추가
+func MergePullEvents(pullEvents []string, records []PullRecord, defaultNode string) []string {
+	seen := make(map[string]bool)
+	merged := make([]string, 0, len(pullEvents)+len(records))
+	for _, line := range pullEvents {
+		if record, ok := ParsePullRecord(line, defaultNode); ok {
+			seen[record.Key()] = true
+		}
+		merged = append(merged, line)
+	}
+	for _, record := range records {
+		if seen[record.Key()] {
+			continue
+		}
+		merged = append(merged, record.LogLine())
+	}
+	return merged
+}
+
+// segmentPath 종류와 날짜의 세그먼트 파일 경로
+func (s *Store) segmentPath(kind, day string) string {
+	return filepath.Join(s.Dir, kind, day+".jsonl")
+}
+
+// segmentsBetween from과 to 사이의 기록을 담을 수 있는 세그먼트 경로 (zero 시각은 제한 없음)
+func (s *Store) segmentsBetween(kind string, from, to time.Time) ([]string, error) {
+	segments, err := s.Segments()
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, segment := range segments {
+		if segment.Kind != kind {
+			continue
+		}
+		if !from.IsZero() && segment.Day.AddDate(0, 0, 1).Before(from) {
+			continue
+		}
+		if !to.IsZero() && segment.Day.After(to) {
+			continue
+		}
+		paths = append(paths, segment.Path)
+	}
+	return paths, nil
+}
+
+// inRange from <= t < to 인지 확인 (zero 시각은 제한 없음)
+func inRange(t, from, to time.Time) bool {
+	if !from.IsZero() && t.Before(from) {
+		return false
+	}
+	if !to.IsZero() && !t.Before(to) {
+		return false
+	}
+	return true
+}
+
+// readPullRecords 세그먼트의 풀 이벤트를 읽음 (파일이 없으면 빈 목록)
+func (s *Store) readPullRecords(path string) ([]PullRecord, error) {
+	var records []PullRecord
+	err := s.readLines(path, func(data []byte) error {
+		var record PullRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			return err
+		}
+		records = append(records, record)
+		return nil
+	})
+	return records, err
+}
+
+// readLines 세그먼트의 JSON 라인마다 fn을 호출 (파일이 없으면 아무것도 하지 않음)
+// 쓰는 도중 중단되어 잘렸거나 fn이 오류를 반환한 라인은 건너뛰고, 건너뛴 라인 수를 Warnf로 알림
+func (s *Store) readLines(path string, fn func(data []byte) error) error {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %v", path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	var skipped int
+	var firstErr error
+	lineNo := 0
+	firstLine := 0
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Bytes()
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+		if err := fn(line); err != nil {
+			skipped++
+			if firstErr == nil {
+				firstErr, firstLine = err, lineNo
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	if skipped > 0 && s.Warnf != nil {
+		s.Warnf("skipped %d unreadable line(s) in %s (line %d: %v)", skipped, path, firstLine, firstErr)
+	}
+	return nil
+}
+
+// appendRecords 기록을 JSON 라인으로 세그먼트 끝에 추가
+func appendRecords(path string, records []any) error {
+	if len(records) == 0 {
+		return nil
+	}
+	var b strings.Builder
+	for _, record := range records {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("failed to encode history record: %v", err)
+		}
+		b.Write(data)
+		b.WriteByte('\n')
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %v", path, err)
+	}
+	if _, err := file.WriteString(b.String()); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %v", path, err)
+	}
+	return nil
+}
+
+// sortedDays 날짜 키를 정렬
+func sortedDays(byDay map[string][]PullRecord) []string {
+	days := make([]string, 0, len(byDay))
+	for day := range byDay {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+	return days
+}
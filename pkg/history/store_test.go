@@ -0,0 +1,242 @@
+package history
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// pullLine CRI-O journal 형식의 풀 이벤트 로그 라인 (id가 비어 있으면 요청 ID 없음)
+func pullLine(at time.Time, node, reference, id string) string {
+	line := fmt.Sprintf(`%s %s crio[581]: time="%s" level=info msg="Pulled image: %s"`,
+		at.Format(time.Stamp), node, at.Format(crioTimeLayout), reference)
+	if id != "" {
+		line += " id=" + id
+	}
+	return line + " name=/runtime.v1.ImageService/PullImage"
+}
+
+func openTestStore(t *testing.T, retention time.Duration) *Store {
+	t.Helper()
+	store, err := Open(t.TempDir(), retention)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	return store
+}
+
+func TestAddPullEventsDedup(t *testing.T) {
+	store := openTestStore(t, 0)
+	at := time.Date(2025, 2, 24, 8, 58, 42, 0, time.UTC)
+	nginx := "docker.io/library/nginx:1.25"
+
+	lines := []string{
+		pullLine(at, "node-1", nginx, "a1"),
+		pullLine(at, "node-1", nginx, "a1"),
+		// 같은 초에 반복된 풀도 요청 ID가 다르면 별도 기록
+		pullLine(at, "node-1", nginx, "b2"),
+		// 요청 ID가 없는 라인은 노드, 시각, 참조로 구분
+		pullLine(at, "node-2", nginx, ""),
+		pullLine(at, "node-2", nginx, ""),
+	}
+	added, err := store.AddPullEvents(lines, "")
+	if err != nil {
+		t.Fatalf("AddPullEvents() error = %v", err)
+	}
+	if added != 3 {
+		t.Errorf("AddPullEvents() added %d, want 3", added)
+	}
+
+	// 다른 프로세스가 같은 journal 구간을 다시 기록해도 추가되지 않음
+	added, err = store.AddPullEvents(lines, "")
+	if err != nil {
+		t.Fatalf("AddPullEvents() error = %v", err)
+	}
+	if added != 0 {
+		t.Errorf("second AddPullEvents() added %d, want 0", added)
+	}
+
+	records, err := store.Pulls(time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("Pulls() error = %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Pulls() returned %d records, want 3: %+v", len(records), records)
+	}
+	ids := map[string]bool{}
+	for _, record := range records {
+		ids[record.ID] = true
+	}
+	if !ids["a1"] || !ids["b2"] || !ids[""] {
+		t.Errorf("Pulls() IDs = %v, want a1, b2 and an empty ID", ids)
+	}
+}
+
+func TestPullsRange(t *testing.T) {
+	store := openTestStore(t, 0)
+	day := time.Date(2025, 2, 24, 0, 0, 0, 0, time.UTC)
+	times := []time.Time{
+		day.Add(-time.Second),
+		day,
+		day.Add(12 * time.Hour),
+		day.AddDate(0, 0, 1),
+	}
+	var lines []string
+	for i, at := range times {
+		lines = append(lines, pullLine(at, "node-1", "docker.io/library/nginx:1.25", fmt.Sprint(i)))
+	}
+	if _, err := store.AddPullEvents(lines, ""); err != nil {
+		t.Fatalf("AddPullEvents() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     []string
+	}{
+		{"unbounded", time.Time{}, time.Time{}, []string{"0", "1", "2", "3"}},
+		{"from is inclusive", day, time.Time{}, []string{"1", "2", "3"}},
+		{"to is exclusive", time.Time{}, day.AddDate(0, 0, 1), []string{"0", "1", "2"}},
+		{"one day", day, day.AddDate(0, 0, 1), []string{"1", "2"}},
+		{"empty range", day.Add(time.Hour), day.Add(time.Hour), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := store.Pulls(tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("Pulls() error = %v", err)
+			}
+			var got []string
+			for _, record := range records {
+				got = append(got, record.ID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Pulls() IDs = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrune(t *testing.T) {
+	store := openTestStore(t, 48*time.Hour)
+	for _, day := range []string{"2025-02-20", "2025-02-21", "2025-02-22"} {
+		for _, kind := range []string{KindPulls, KindRateLimits} {
+			if err := os.WriteFile(store.segmentPath(kind, day), []byte("{}\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	tests := []struct {
+		now  time.Time
+		want []string
+	}{
+		// cutoff 2025-02-22 00:00: 02-20 세그먼트는 02-21 00:00에 끝나므로 삭제
+		{time.Date(2025, 2, 24, 0, 0, 0, 0, time.UTC), []string{"2025-02-20"}},
+		// cutoff가 02-21 세그먼트의 끝과 같으면 아직 보관
+		{time.Date(2025, 2, 24, 0, 0, 0, 0, time.UTC), nil},
+		{time.Date(2025, 2, 24, 0, 0, 1, 0, time.UTC), []string{"2025-02-21"}},
+	}
+	for _, tt := range tests {
+		pruned, err := store.Prune(tt.now)
+		if err != nil {
+			t.Fatalf("Prune(%s) error = %v", tt.now, err)
+		}
+		var got []string
+		for _, segment := range pruned {
+			if segment.Kind == KindPulls {
+				got = append(got, segment.Day.Format(segmentLayout))
+			}
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("Prune(%s) pruned %v, want %v", tt.now, got, tt.want)
+		}
+		if len(pruned) != 2*len(tt.want) {
+			t.Errorf("Prune(%s) pruned %d segments, want %d", tt.now, len(pruned), 2*len(tt.want))
+		}
+	}
+
+	segments, err := store.Segments()
+	if err != nil {
+		t.Fatalf("Segments() error = %v", err)
+	}
+	if len(segments) != 2 {
+		t.Errorf("%d segments left, want 2: %+v", len(segments), segments)
+	}
+
+	// Retention이 0이면 삭제하지 않음
+	store.Retention = 0
+	if pruned, err := store.Prune(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)); err != nil || len(pruned) != 0 {
+		t.Errorf("Prune() with no retention = %v, %v, want nothing pruned", pruned, err)
+	}
+}
+
+func TestMergePullEvents(t *testing.T) {
+	at := time.Date(2025, 2, 24, 8, 58, 42, 0, time.UTC)
+	nginx := "docker.io/library/nginx:1.25"
+	journal := []string{pullLine(at, "node-1", nginx, "a1")}
+
+	stored := []PullRecord{
+		// journal에도 남아 있는 풀
+		{Time: at, Node: "node-1", Reference: nginx, ID: "a1"},
+		// 같은 초의 다른 풀
+		{Time: at, Node: "node-1", Reference: nginx, ID: "b2"},
+		// journal에서 이미 사라진 오래된 풀
+		{Time: at.Add(-48 * time.Hour), Node: "node-2", Reference: "quay.io/calico/cni:v3.27.0"},
+	}
+	merged := MergePullEvents(journal, stored, "")
+	if len(merged) != 3 {
+		t.Fatalf("MergePullEvents() returned %d lines, want 3:\n%s", len(merged), strings.Join(merged, "\n"))
+	}
+	if merged[0] != journal[0] {
+		t.Errorf("merged[0] = %q, want the journal line first", merged[0])
+	}
+	// 합성 라인은 원래 레코드로 다시 해석되어야 함
+	for i, line := range merged[1:] {
+		record, ok := ParsePullRecord(line, "")
+		if !ok {
+			t.Fatalf("ParsePullRecord(%q) failed", line)
+		}
+		if want := stored[i+1]; record.Key() != want.Key() {
+			t.Errorf("merged line %q parsed as %+v, want %+v", line, record, want)
+		}
+	}
+}
+
+func TestReadSkipsCorruptLines(t *testing.T) {
+	store := openTestStore(t, 0)
+	var warnings []string
+	store.Warnf = func(format string, args ...any) {
+		warnings = append(warnings, fmt.Sprintf(format, args...))
+	}
+
+	at := time.Date(2025, 2, 24, 8, 58, 42, 0, time.UTC)
+	path := store.segmentPath(KindPulls, at.Format(segmentLayout))
+	content := `{"time":"2025-02-24T08:58:42Z","node":"node-1","reference":"docker.io/library/nginx:1.25","id":"a1"}
+{"time":"2025-02-24T08:59:00Z","node":"node-1","refer
+not json
+
+{"time":"2025-02-24T09:00:00Z","node":"node-2","reference":"docker.io/library/redis:7"}
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := store.Pulls(time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("Pulls() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Errorf("Pulls() returned %d records, want 2", len(records))
+	}
+	if len(warnings) != 1 {
+		t.Fatalf("Warnf called %d times, want 1: %v", len(warnings), warnings)
+	}
+	if !strings.Contains(warnings[0], "skipped 2 unreadable line(s)") || !strings.Contains(warnings[0], filepath.Base(path)) ||
+		!strings.Contains(warnings[0], "line 2") {
+		t.Errorf("warning = %q, want skipped count, segment path and first bad line", warnings[0])
+	}
+}
@@ -0,0 +1,132 @@
+package history
+
+import (
+	"sort"
+	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// RegistryPulls 레지스트리 하나의 저장된 풀 이벤트 집계
+type RegistryPulls struct {
+	Registry string
+	Pulls    int
+	Images   int
+	Nodes    int
+	First    time.Time
+	Last     time.Time
+}
+
+// RateLimitTrend 레지스트리/리소스/계정 하나의 rate limit 샘플 추이
+type RateLimitTrend struct {
+	Registry string
+	Resource string
+	Identity string
+	Unit     string
+	Samples  int
+	Limit    int64
+	// MinRemaining 기간 중 가장 적게 남았던 양
+	MinRemaining int64
+	// LastRemaining 마지막 샘플의 남은 양
+	LastRemaining int64
+	// Throttled 제한에 걸린 샘플 수
+	Throttled int
+	// Errors 조회에 실패한 샘플 수
+	Errors int
+	Last   time.Time
+}
+
+// SummarizePulls 풀 이벤트를 레지스트리별로 집계 (풀 횟수가 많은 순)
+func SummarizePulls(records []PullRecord) []RegistryPulls {
+	type accumulator struct {
+		summary RegistryPulls
+		images  map[string]bool
+		nodes   map[string]bool
+	}
+	byRegistry := make(map[string]*accumulator)
+	for _, record := range records {
+		ref, err := registry.ParseReference(record.Reference)
+		if err != nil {
+			continue
+		}
+		acc, ok := byRegistry[ref.Registry]
+		if !ok {
+			acc = &accumulator{
+				summary: RegistryPulls{Registry: ref.Registry, First: record.Time},
+				images:  make(map[string]bool),
+				nodes:   make(map[string]bool),
+			}
+			byRegistry[ref.Registry] = acc
+		}
+		acc.summary.Pulls++
+		acc.images[ref.Name()] = true
+		if record.Node != "" {
+			acc.nodes[record.Node] = true
+		}
+		if record.Time.Before(acc.summary.First) {
+			acc.summary.First = record.Time
+		}
+		if record.Time.After(acc.summary.Last) {
+			acc.summary.Last = record.Time
+		}
+	}
+
+	summaries := make([]RegistryPulls, 0, len(byRegistry))
+	for _, acc := range byRegistry {
+		acc.summary.Images = len(acc.images)
+		acc.summary.Nodes = len(acc.nodes)
+		summaries = append(summaries, acc.summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Pulls != summaries[j].Pulls {
+			return summaries[i].Pulls > summaries[j].Pulls
+		}
+		return summaries[i].Registry < summaries[j].Registry
+	})
+	return summaries
+}
+
+// SummarizeRateLimits 시각 순 샘플을 레지스트리/리소스/계정별 추이로 집계
+func SummarizeRateLimits(samples []RateLimitSample) []RateLimitTrend {
+	byKey := make(map[string]*RateLimitTrend)
+	var keys []string
+	for _, sample := range samples {
+		key := sample.Registry + "|" + sample.Resource + "|" + sample.Identity
+		trend, ok := byKey[key]
+		if !ok {
+			trend = &RateLimitTrend{
+				Registry:     sample.Registry,
+				Resource:     sample.Resource,
+				Identity:     sample.Identity,
+				MinRemaining: -1,
+			}
+			byKey[key] = trend
+			keys = append(keys, key)
+		}
+		trend.Samples++
+		trend.Last = sample.Time
+		if sample.Error != "" {
+			trend.Errors++
+			continue
+		}
+		if sample.Throttled {
+			trend.Throttled++
+		}
+		if sample.Limit <= 0 {
+			continue
+		}
+		trend.Unit = sample.Unit
+		trend.Limit = sample.Limit
+		trend.LastRemaining = sample.Remaining
+		if trend.MinRemaining < 0 || sample.Remaining < trend.MinRemaining {
+			trend.MinRemaining = sample.Remaining
+		}
+	}
+
+	sort.Strings(keys)
+	trends := make([]RateLimitTrend, 0, len(keys))
+	for _, key := range keys {
+		trends = append(trends, *byKey[key])
+	}
+	return trends
+}
@@ -0,0 +1,96 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+const (
+	// DefaultTimeout 요청 전체에 적용되는 기본 타임아웃
+	DefaultTimeout = 30 * time.Second
+	// DefaultUserAgent 기본 User-Agent 헤더 값
+	DefaultUserAgent = "zim/1.0.0"
+)
+
+// Config HTTP 클라이언트 설정
+type Config struct {
+	Timeout            time.Duration
+	UserAgent          string
+	ProxyURL           string
+	CAFile             string
+	InsecureSkipVerify bool
+}
+
+// userAgentTransport 모든 요청에 User-Agent 헤더를 설정하는 RoundTripper
+type userAgentTransport struct {
+	base      http.RoundTripper
+	userAgent string
+}
+
+// RoundTrip 원본 요청을 복사하여 User-Agent 헤더를 설정한 뒤 전달
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// New 설정에 따라 타임아웃, 프록시, TLS, User-Agent가 적용된 HTTP 클라이언트를 생성
+func New(cfg Config) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	// 프록시 설정 (지정하지 않으면 HTTP_PROXY/HTTPS_PROXY 환경 변수 사용)
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %q: %v", cfg.ProxyURL, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	// TLS 설정
+	if cfg.CAFile != "" || cfg.InsecureSkipVerify {
+		tlsConfig := &tls.Config{InsecureSkipVerify: cfg.InsecureSkipVerify}
+		if cfg.CAFile != "" {
+			caPEM, err := os.ReadFile(cfg.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA file: %v", err)
+			}
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				pool = x509.NewCertPool()
+			}
+			if !pool.AppendCertsFromPEM(caPEM) {
+				return nil, fmt.Errorf("no certificates found in CA file %s", cfg.CAFile)
+			}
+			tlsConfig.RootCAs = pool
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	userAgent := cfg.UserAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &userAgentTransport{base: transport, userAgent: userAgent},
+	}, nil
+}
+
+// Default 기본 설정이 적용된 HTTP 클라이언트를 반환
+func Default() *http.Client {
+	client, _ := New(Config{})
+	return client
+}
@@ -0,0 +1,178 @@
+package imageage
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// CreatedLabel 이미지 생성 시각을 기록하는 OCI 표준 레이블
+const CreatedLabel = "org.opencontainers.image.created"
+
+// 생성 시각 출처
+const (
+	SourceLabel  = "label"
+	SourceConfig = "config"
+)
+
+// ImageAge 워크로드 컨테이너 하나가 실행 중인 이미지의 생성 시각
+type ImageAge struct {
+	Namespace string
+	Workload  string
+	Container string
+	Image     string
+	Digest    string
+	Pods      int
+	// Created 이미지 생성 시각 (모르면 nil)
+	Created *time.Time
+	// Source 생성 시각 출처 (label 또는 config)
+	Source string
+	// Error config 조회에 실패한 경우의 오류 메시지
+	Error string
+}
+
+// Age 기준 시각까지의 이미지 나이 (생성 시각을 모르면 0)
+func (a ImageAge) Age(now time.Time) time.Duration {
+	if a.Created == nil {
+		return 0
+	}
+	return now.Sub(*a.Created)
+}
+
+// imageCreated digest별 생성 시각 조회 결과
+type imageCreated struct {
+	created *time.Time
+	source  string
+	err     error
+}
+
+// Checker 레지스트리의 이미지 config에서 생성 시각을 조회
+type Checker struct {
+	Client *registry.Client
+	// DefaultPlatform 노드 플랫폼을 모를 때 멀티 아키텍처 이미지에서 선택할 플랫폼
+	DefaultPlatform registry.Platform
+	images          map[string]*imageCreated
+}
+
+// NewChecker 레지스트리 클라이언트로 Checker를 생성
+func NewChecker(client *registry.Client, platform registry.Platform) *Checker {
+	return &Checker{Client: client, DefaultPlatform: platform, images: make(map[string]*imageCreated)}
+}
+
+// Check 실행 중인 컨테이너를 워크로드/컨테이너/digest별로 묶어 이미지 생성 시각을 조회
+func (c *Checker) Check(ctx context.Context, containers []kubernetes.RunningContainer, nodes []kubernetes.NodePlatform) []ImageAge {
+	nodePlatforms := make(map[string]registry.Platform, len(nodes))
+	for _, node := range nodes {
+		nodePlatforms[node.Name] = registry.Platform{OS: node.OS, Architecture: node.Architecture}
+	}
+
+	// 멀티 아키텍처 이미지는 노드 플랫폼마다 config가 다르므로 플랫폼도 구분
+	type groupKey struct{ namespace, workload, container, image, digest, platform string }
+	groups := make(map[groupKey]*ImageAge)
+	var keys []groupKey
+	for _, container := range containers {
+		platform, ok := nodePlatforms[container.Node]
+		if !ok {
+			platform = c.DefaultPlatform
+		}
+		key := groupKey{container.Namespace, container.Workload, container.Container, container.Image, container.Digest(), platform.String()}
+		age, ok := groups[key]
+		if !ok {
+			age = &ImageAge{
+				Namespace: container.Namespace,
+				Workload:  container.Workload,
+				Container: container.Container,
+				Image:     container.Image,
+				Digest:    container.Digest(),
+			}
+			groups[key] = age
+			keys = append(keys, key)
+
+			result := c.created(ctx, container, platform)
+			age.Created, age.Source = result.created, result.source
+			if result.err != nil {
+				age.Error = result.err.Error()
+			}
+		}
+		age.Pods++
+	}
+
+	ages := make([]ImageAge, 0, len(keys))
+	for _, key := range keys {
+		ages = append(ages, *groups[key])
+	}
+	// 오래된 이미지부터 정렬 (생성 시각을 모르는 이미지는 마지막)
+	sort.SliceStable(ages, func(i, j int) bool {
+		if (ages[i].Created == nil) != (ages[j].Created == nil) {
+			return ages[i].Created != nil
+		}
+		if ages[i].Created != nil && !ages[i].Created.Equal(*ages[j].Created) {
+			return ages[i].Created.Before(*ages[j].Created)
+		}
+		return ages[i].Namespace+"/"+ages[i].Workload < ages[j].Namespace+"/"+ages[j].Workload
+	})
+	return ages
+}
+
+// created 실행 중인 digest(없으면 태그)의 config에서 생성 시각을 조회 (결과는 캐시)
+func (c *Checker) created(ctx context.Context, container kubernetes.RunningContainer, platform registry.Platform) *imageCreated {
+	ref, err := registry.ParseReference(container.Image)
+	if err != nil {
+		return &imageCreated{err: err}
+	}
+	if container.RepoDigest != "" {
+		ref = ref.WithDigest(container.RepoDigest)
+	}
+	key := ref.String() + " " + platform.String()
+	if container.RepoDigest == "" && container.ConfigDigest != "" {
+		key = ref.Name() + "@" + container.ConfigDigest
+	}
+	if result, ok := c.images[key]; ok {
+		return result
+	}
+	result := &imageCreated{}
+	c.images[key] = result
+
+	// imageID가 config digest인 런타임은 config blob을 바로 조회
+	if container.RepoDigest == "" && container.ConfigDigest != "" {
+		manifest := &registry.Manifest{Config: registry.Descriptor{Digest: container.ConfigDigest}}
+		if config, err := c.Client.GetConfig(ctx, ref, manifest); err == nil {
+			result.created, result.source = ConfigCreated(config)
+			return result
+		}
+	}
+
+	manifest, err := c.Client.GetManifest(ctx, ref)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	manifest, err = c.Client.PlatformManifest(ctx, ref, manifest, platform)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	config, err := c.Client.GetConfig(ctx, ref, manifest)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	result.created, result.source = ConfigCreated(config)
+	return result
+}
+
+// ConfigCreated 이미지 config의 생성 시각을 반환 (OCI created 레이블 우선, 재현 가능한 빌드의 epoch 0은 무시)
+func ConfigCreated(config *registry.ImageConfig) (*time.Time, string) {
+	if value := config.Config.Labels[CreatedLabel]; value != "" {
+		if created, err := time.Parse(time.RFC3339, value); err == nil && created.Unix() > 0 {
+			return &created, SourceLabel
+		}
+	}
+	if config.Created != nil && config.Created.Unix() > 0 {
+		return config.Created, SourceConfig
+	}
+	return nil, ""
+}
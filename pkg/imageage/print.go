@@ -0,0 +1,79 @@
+package imageage
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/units"
+)
+
+// CountStale 최대 나이를 넘은 이미지 수 (maxAge가 0이면 항상 0)
+func CountStale(ages []ImageAge, maxAge time.Duration, now time.Time) int {
+	if maxAge <= 0 {
+		return 0
+	}
+	var stale int
+	for _, age := range ages {
+		if age.Created != nil && age.Age(now) > maxAge {
+			stale++
+		}
+	}
+	return stale
+}
+
+// PrintImageAges 워크로드별 이미지 생성 시각과 나이 출력 (maxAge가 있으면 초과 여부 표시)
+func PrintImageAges(ages []ImageAge, maxAge time.Duration, now time.Time) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "\nImage Age Report:\n")
+	fmt.Fprintln(w, "=======================================================================")
+	fmt.Fprintln(w, "No.\tNamespace\tWorkload\tContainer\tImage\tPods\tCreated\tAge\tSource\tStatus")
+	fmt.Fprintln(w, "-----------------------------------------------------------------------")
+	for i, age := range ages {
+		created, ageText, source, status := "-", "-", "-", "UNKNOWN"
+		if age.Created != nil {
+			created = age.Created.Format("2006-01-02")
+			ageText = units.FormatAge(age.Age(now))
+			source = age.Source
+			status = "OK"
+			if maxAge > 0 && age.Age(now) > maxAge {
+				status = "STALE"
+			}
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\n", i+1, age.Namespace, age.Workload, age.Container,
+			age.Image, age.Pods, created, ageText, source, status)
+	}
+	fmt.Fprintln(w, "=======================================================================")
+	w.Flush()
+
+	// 요약 정보 출력
+	var known int
+	var total time.Duration
+	var oldest *ImageAge
+	for i, age := range ages {
+		if age.Created == nil {
+			continue
+		}
+		known++
+		total += age.Age(now)
+		if oldest == nil || age.Created.Before(*oldest.Created) {
+			oldest = &ages[i]
+		}
+	}
+	fmt.Printf("\nSummary:\n")
+	fmt.Printf("- Containers checked: %d\n", len(ages))
+	fmt.Printf("- Unknown creation time: %d\n", len(ages)-known)
+	if known > 0 {
+		fmt.Printf("- Average image age: %s\n", units.FormatAge(total/time.Duration(known)))
+		fmt.Printf("- Oldest image: %s (%s)\n", oldest.Image, units.FormatAge(oldest.Age(now)))
+	}
+	if maxAge > 0 {
+		fmt.Printf("- Older than %s: %d\n", units.FormatAge(maxAge), CountStale(ages, maxAge, now))
+	}
+	for _, age := range ages {
+		if age.Error != "" {
+			fmt.Printf("  - %s: %s\n", age.Image, age.Error)
+		}
+	}
+}
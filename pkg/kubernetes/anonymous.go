@@ -0,0 +1,266 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// AnonymousPull Docker Hub 인증 정보 없이 이미지를 가져오는 워크로드 정보
+type AnonymousPull struct {
+	Namespace string
+	Workload  string
+	Image     string
+	// PullCount 이미지 단위 풀 횟수 (같은 이미지를 쓰는 모든 워크로드에 같은 값이므로 워크로드끼리 합산하면 안 됨)
+	PullCount int
+}
+
+// pullSecretResolver 네임스페이스별 Secret과 ServiceAccount 조회 결과를 캐시
+type pullSecretResolver struct {
+	clientset       *kubernetes.Clientset
+	secrets         map[string]bool
+	serviceAccounts map[string][]corev1.LocalObjectReference
+}
+
+// GetAnonymousDockerHubPulls docker.io 이미지를 익명으로 가져오는 워크로드 목록을 조회
+func GetAnonymousDockerHubPulls(clientset *kubernetes.Clientset, pullEvents []string) ([]AnonymousPull, error) {
+	pods, err := clientset.CoreV1().Pods("").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %v", err)
+	}
+
+	pullCounts := CountPullsByImage(pullEvents)
+	resolver := &pullSecretResolver{
+		clientset:       clientset,
+		secrets:         make(map[string]bool),
+		serviceAccounts: make(map[string][]corev1.LocalObjectReference),
+	}
+
+	// 같은 워크로드의 여러 레플리카는 한 번만 집계
+	seen := make(map[string]bool)
+	var pulls []AnonymousPull
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		workload := podWorkload(pod)
+
+		for _, image := range podContainerImages(pod) {
+			ref, err := registry.ParseReference(image)
+			if err != nil || ref.Registry != registry.DockerHubRegistry {
+				continue
+			}
+
+			key := pod.Namespace + "/" + workload + "/" + ref.Name()
+			if seen[key] {
+				continue
+			}
+
+			hasCredentials, err := resolver.hasDockerHubCredentials(pod)
+			if err != nil {
+				return nil, err
+			}
+			seen[key] = true
+			if hasCredentials {
+				continue
+			}
+
+			pulls = append(pulls, AnonymousPull{
+				Namespace: pod.Namespace,
+				Workload:  workload,
+				Image:     ref.Name(),
+				PullCount: pullCounts[ref.Name()],
+			})
+		}
+	}
+
+	sort.Slice(pulls, func(i, j int) bool {
+		if pulls[i].PullCount != pulls[j].PullCount {
+			return pulls[i].PullCount > pulls[j].PullCount
+		}
+		if pulls[i].Namespace != pulls[j].Namespace {
+			return pulls[i].Namespace < pulls[j].Namespace
+		}
+		if pulls[i].Workload != pulls[j].Workload {
+			return pulls[i].Workload < pulls[j].Workload
+		}
+		return pulls[i].Image < pulls[j].Image
+	})
+
+	return pulls, nil
+}
+
+// hasDockerHubCredentials Pod 스펙 또는 ServiceAccount의 imagePullSecret 중 docker.io 인증 정보가 있는지 확인
+func (r *pullSecretResolver) hasDockerHubCredentials(pod *corev1.Pod) (bool, error) {
+	secretRefs := append([]corev1.LocalObjectReference{}, pod.Spec.ImagePullSecrets...)
+
+	saRefs, err := r.serviceAccountPullSecrets(pod.Namespace, pod.Spec.ServiceAccountName)
+	if err != nil {
+		return false, err
+	}
+	secretRefs = append(secretRefs, saRefs...)
+
+	for _, secretRef := range secretRefs {
+		ok, err := r.secretHasDockerHubAuth(pod.Namespace, secretRef.Name)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// serviceAccountPullSecrets ServiceAccount에 연결된 imagePullSecret 목록을 조회
+func (r *pullSecretResolver) serviceAccountPullSecrets(namespace, name string) ([]corev1.LocalObjectReference, error) {
+	if name == "" {
+		name = "default"
+	}
+	key := namespace + "/" + name
+	if refs, ok := r.serviceAccounts[key]; ok {
+		return refs, nil
+	}
+
+	sa, err := r.clientset.CoreV1().ServiceAccounts(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			r.serviceAccounts[key] = nil
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get service account %s: %v", key, err)
+	}
+
+	r.serviceAccounts[key] = sa.ImagePullSecrets
+	return sa.ImagePullSecrets, nil
+}
+
+// secretHasDockerHubAuth Secret에 docker.io 레지스트리 인증 정보가 포함되어 있는지 확인
+func (r *pullSecretResolver) secretHasDockerHubAuth(namespace, name string) (bool, error) {
+	key := namespace + "/" + name
+	if ok, cached := r.secrets[key]; cached {
+		return ok, nil
+	}
+
+	secret, err := r.clientset.CoreV1().Secrets(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		// 존재하지 않는 Secret은 kubelet도 무시하므로 인증 정보가 없는 것으로 취급
+		if apierrors.IsNotFound(err) {
+			r.secrets[key] = false
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get secret %s: %v", key, err)
+	}
+
+	ok := false
+	for _, host := range dockerConfigHosts(secret) {
+		if registry.IsDockerHub(host) {
+			ok = true
+			break
+		}
+	}
+	r.secrets[key] = ok
+	return ok, nil
+}
+
+// dockerConfigHosts dockerconfigjson 또는 dockercfg 형식의 Secret에서 레지스트리 호스트 목록을 추출
+func dockerConfigHosts(secret *corev1.Secret) []string {
+	var auths map[string]json.RawMessage
+
+	switch secret.Type {
+	case corev1.SecretTypeDockerConfigJson:
+		var config struct {
+			Auths map[string]json.RawMessage `json:"auths"`
+		}
+		if err := json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &config); err != nil {
+			return nil
+		}
+		auths = config.Auths
+	case corev1.SecretTypeDockercfg:
+		if err := json.Unmarshal(secret.Data[corev1.DockerConfigKey], &auths); err != nil {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	var hosts []string
+	for host := range auths {
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
+
+// PrintAnonymousDockerHubPulls 익명 Docker Hub 풀 워크로드 목록과 네임스페이스별 요약 출력
+func PrintAnonymousDockerHubPulls(pulls []AnonymousPull, since int) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "\nAnonymous Docker Hub Pulls (Last %d hours):\n", since)
+	fmt.Fprintln(w, "=======================================================================")
+	fmt.Fprintln(w, "No.\tNamespace\tWorkload\tImage\tImage Pulls")
+	fmt.Fprintln(w, "-----------------------------------------------------------------------")
+	for i, pull := range pulls {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%d\n", i+1, pull.Namespace, pull.Workload, pull.Image, pull.PullCount)
+	}
+	fmt.Fprintln(w, "=======================================================================")
+	w.Flush()
+
+	// 네임스페이스별 집계 (같은 이미지는 한 번만 합산)
+	type namespaceSummary struct {
+		workloads map[string]bool
+		images    map[string]int
+	}
+	summaries := make(map[string]*namespaceSummary)
+	var namespaces []string
+	for _, pull := range pulls {
+		summary, ok := summaries[pull.Namespace]
+		if !ok {
+			summary = &namespaceSummary{workloads: make(map[string]bool), images: make(map[string]int)}
+			summaries[pull.Namespace] = summary
+			namespaces = append(namespaces, pull.Namespace)
+		}
+		summary.workloads[pull.Workload] = true
+		summary.images[pull.Image] = pull.PullCount
+	}
+	sort.Strings(namespaces)
+
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintln(w, "\nNamespace\tWorkloads\tImages\tImage Pulls")
+	fmt.Fprintln(w, "-----------------------------------------------------------------------")
+	for _, namespace := range namespaces {
+		summary := summaries[namespace]
+		var namespacePulls int
+		for _, count := range summary.images {
+			namespacePulls += count
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\n", namespace, len(summary.workloads), len(summary.images), namespacePulls)
+	}
+	w.Flush()
+
+	// 한 워크로드가 여러 이미지를 쓰면 행이 여러 개이므로 워크로드는 네임스페이스/이름으로 한 번만 집계
+	// 여러 워크로드가 같은 이미지를 써도 클러스터 전체 풀 횟수는 이미지마다 한 번만 합산
+	workloads := make(map[string]bool)
+	imagePulls := make(map[string]int)
+	for _, pull := range pulls {
+		workloads[pull.Namespace+"/"+pull.Workload] = true
+		imagePulls[pull.Image] = pull.PullCount
+	}
+	var totalPulls int
+	for _, count := range imagePulls {
+		totalPulls += count
+	}
+
+	fmt.Printf("\nSummary:\n")
+	fmt.Printf("- Period: Last %d hours\n", since)
+	fmt.Printf("- Anonymous workloads: %d\n", len(workloads))
+	fmt.Printf("- Namespaces: %d\n", len(namespaces))
+	fmt.Printf("- Pulls of anonymously used images: %d across %d images (includes authenticated pulls of the same images)\n", totalPulls, len(imagePulls))
+	fmt.Printf("- Note: Image Pulls counts every pull of the image, not pulls caused by that workload; do not add it up across workloads\n")
+	fmt.Printf("- Note: node-level credentials (kubelet config.json) are not inspected\n")
+}
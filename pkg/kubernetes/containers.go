@@ -0,0 +1,103 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// RunningContainer 컨테이너 상태에 기록된 실제 실행 이미지 정보
+type RunningContainer struct {
+	Namespace string
+	Pod       string
+	Workload  string
+	Node      string
+	Container string
+	Init      bool
+	// Image Pod 스펙에 지정된 이미지 참조
+	Image string
+	// ImageID 컨테이너 상태의 imageID 원문
+	ImageID string
+	// RepoDigest imageID가 "<name>@sha256:..." 형식일 때의 매니페스트 digest
+	RepoDigest string
+	// ConfigDigest imageID가 "sha256:..." 형식(이미지 ID)일 때의 config digest
+	ConfigDigest string
+	StartedAt    time.Time
+}
+
+// Digest 실행 중인 이미지의 digest (매니페스트 digest 우선)
+func (c RunningContainer) Digest() string {
+	if c.RepoDigest != "" {
+		return c.RepoDigest
+	}
+	return c.ConfigDigest
+}
+
+// GetRunningContainers 네임스페이스(빈 문자열이면 전체)의 Pod에서 이미지가 확인된 컨테이너 목록을 조회
+func GetRunningContainers(clientset *kubernetes.Clientset, namespace string) ([]RunningContainer, error) {
+	pods, err := clientset.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %v", err)
+	}
+
+	var containers []RunningContainer
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		workload := podWorkload(pod)
+		add := func(statuses []corev1.ContainerStatus, specs []corev1.Container, init bool) {
+			for _, status := range statuses {
+				if status.ImageID == "" {
+					continue
+				}
+				container := RunningContainer{
+					Namespace: pod.Namespace,
+					Pod:       pod.Name,
+					Workload:  workload,
+					Node:      pod.Spec.NodeName,
+					Container: status.Name,
+					Init:      init,
+					Image:     status.Image,
+					ImageID:   status.ImageID,
+				}
+				// 상태의 image는 런타임이 정규화한 값일 수 있으므로 스펙의 참조를 우선 사용
+				for _, spec := range specs {
+					if spec.Name == status.Name {
+						container.Image = spec.Image
+						break
+					}
+				}
+				container.RepoDigest, container.ConfigDigest = parseImageID(status.ImageID)
+				if status.State.Running != nil {
+					container.StartedAt = status.State.Running.StartedAt.Time
+				}
+				containers = append(containers, container)
+			}
+		}
+		add(pod.Status.ContainerStatuses, pod.Spec.Containers, false)
+		add(pod.Status.InitContainerStatuses, pod.Spec.InitContainers, true)
+	}
+	return containers, nil
+}
+
+// parseImageID 런타임별 imageID 형식에서 매니페스트 digest 또는 config digest를 추출
+// 예: docker-pullable://nginx@sha256:..., docker.io/library/nginx@sha256:..., sha256:..., docker://sha256:...
+func parseImageID(imageID string) (string, string) {
+	if i := strings.Index(imageID, "://"); i != -1 {
+		imageID = imageID[i+3:]
+	}
+	if i := strings.LastIndex(imageID, "@"); i != -1 {
+		return imageID[i+1:], ""
+	}
+	if strings.HasPrefix(imageID, "sha256:") {
+		return "", imageID
+	}
+	return "", ""
+}
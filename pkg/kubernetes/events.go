@@ -0,0 +1,79 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// pulledMessagePattern kubelet "Pulled" 이벤트 메시지 형식
+// 예: Successfully pulled image "nginx:1.25" in 3.2s (3.2s including waiting). Image size: 70520324 bytes.
+var pulledMessagePattern = regexp.MustCompile(`Successfully pulled image "([^"]+)" in ((?:[0-9.]+(?:ns|us|µs|ms|s|m|h))+)(?: \((?:[0-9.]+(?:ns|us|µs|ms|s|m|h))+ including waiting\))?(?:\. Image size: (\d+) bytes)?`)
+
+// KubeletPullEvent kubelet이 기록한 이미지 풀 이벤트
+type KubeletPullEvent struct {
+	Namespace string
+	Pod       string
+	Node      string
+	Image     string
+	Duration  time.Duration
+	SizeBytes int64
+	Time      time.Time
+}
+
+// GetKubeletPullEvents reason=Pulled 이벤트 중 실제로 이미지를 내려받은 이벤트를 조회
+func GetKubeletPullEvents(clientset *kubernetes.Clientset, since time.Time) ([]KubeletPullEvent, error) {
+	events, err := clientset.CoreV1().Events("").List(context.Background(), metav1.ListOptions{
+		FieldSelector: "reason=Pulled",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %v", err)
+	}
+
+	var pulls []KubeletPullEvent
+	for _, event := range events.Items {
+		image, duration, size, ok := parsePulledMessage(event.Message)
+		if !ok {
+			continue
+		}
+
+		eventTime := event.LastTimestamp.Time
+		if eventTime.IsZero() {
+			eventTime = event.EventTime.Time
+		}
+		if !since.IsZero() && eventTime.Before(since) {
+			continue
+		}
+
+		pulls = append(pulls, KubeletPullEvent{
+			Namespace: event.InvolvedObject.Namespace,
+			Pod:       event.InvolvedObject.Name,
+			Node:      event.Source.Host,
+			Image:     image,
+			Duration:  duration,
+			SizeBytes: size,
+			Time:      eventTime,
+		})
+	}
+	return pulls, nil
+}
+
+// parsePulledMessage "Successfully pulled image" 메시지에서 이미지, 소요 시간, 크기를 추출
+func parsePulledMessage(message string) (string, time.Duration, int64, bool) {
+	match := pulledMessagePattern.FindStringSubmatch(message)
+	if match == nil {
+		return "", 0, 0, false
+	}
+
+	duration, _ := time.ParseDuration(match[2])
+	var size int64
+	if match[3] != "" {
+		size, _ = strconv.ParseInt(match[3], 10, 64)
+	}
+	return match[1], duration, size, true
+}
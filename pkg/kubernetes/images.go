@@ -2,13 +2,20 @@ package kubernetes
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"text/tabwriter"
+	"time"
 
+	"github.com/suslmk-lee/zim-image-management/pkg/output"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+	"github.com/suslmk-lee/zim-image-management/pkg/units"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
@@ -34,22 +41,8 @@ func GetPodImages(clientset *kubernetes.Clientset) ([]string, error) {
 	return images, nil
 }
 
-// cleanImageName 이미지 이름에서 태그와 다이제스트를 제거
-func cleanImageName(imageName string) string {
-	// 태그 또는 다이제스트 제거
-	if strings.Contains(imageName, "@sha256:") {
-		parts := strings.Split(imageName, "@sha256:")
-		return parts[0]
-	}
-	if strings.Contains(imageName, ":") {
-		parts := strings.Split(imageName, ":")
-		return parts[0]
-	}
-	return imageName
-}
-
-// extractImageName 로그 라인에서 이미지 이름 추출
-func extractImageName(line string) string {
+// extractPulledImage 로그 라인에서 태그와 다이제스트를 포함한 전체 이미지 참조 추출
+func extractPulledImage(line string) string {
 	parts := strings.Split(line, "Pulled image")
 	if len(parts) < 2 {
 		return ""
@@ -66,7 +59,128 @@ func extractImageName(line string) string {
 		imagePart = imagePart[:quotesIndex]
 	}
 
-	return cleanImageName(imagePart)
+	return strings.TrimSpace(imagePart)
+}
+
+// crioTimePattern CRI-O 로그의 time="2025-02-24 08:58:42.934122405+09:00" 필드
+var crioTimePattern = regexp.MustCompile(`time="([^"]+)"`)
+
+// crioIDPattern CRI-O 로그의 요청 ID 필드 id=e9ccf295-4f1a-44c3-bd87-072e86392509
+var crioIDPattern = regexp.MustCompile(`(?:^|\s)id=([^\s"]+)`)
+
+// PullEvent 풀 이벤트 로그 라인 하나의 내용
+type PullEvent struct {
+	Reference registry.Reference
+	// Node journal 출력의 호스트 이름 (알 수 없으면 빈 문자열)
+	Node string
+	// Time 풀 시각 (알 수 없으면 zero)
+	Time time.Time
+	// ID CRI-O 요청 ID (같은 시각에 같은 이미지를 여러 번 풀해도 구분됨, 없으면 빈 문자열)
+	ID string
+}
+
+// ParsePullEvent 로그 라인에서 풀한 이미지 참조, 노드 이름, 시각, CRI-O 요청 ID를 추출
+// journalctl 기본 형식 "Feb 24 08:58:42 <host> crio[581]: ..."이면 네 번째 필드를 노드 이름으로 사용
+// 시각은 CRI-O time 필드를 우선 사용하고, 없으면 연도가 없는 journal 시각을 최근 1년 안의 로컬 시각으로 해석
+func ParsePullEvent(line string) (PullEvent, bool) {
+	image := extractPulledImage(line)
+	if image == "" {
+		return PullEvent{}, false
+	}
+	ref, err := registry.ParseReference(image)
+	if err != nil {
+		return PullEvent{}, false
+	}
+	event := PullEvent{Reference: ref}
+	fields := strings.Fields(line)
+	if len(fields) > 4 && strings.HasSuffix(fields[4], ":") {
+		event.Node = fields[3]
+	}
+
+	if match := crioIDPattern.FindStringSubmatch(line); match != nil {
+		event.ID = match[1]
+	}
+	if match := crioTimePattern.FindStringSubmatch(line); match != nil {
+		if t, err := time.Parse("2006-01-02 15:04:05.999999999-07:00", match[1]); err == nil {
+			event.Time = t
+		}
+	}
+	if event.Time.IsZero() && len(fields) > 2 {
+		if t, err := time.ParseInLocation(time.Stamp, strings.Join(fields[:3], " "), time.Local); err == nil {
+			now := time.Now()
+			event.Time = t.AddDate(now.Year(), 0, 0)
+			if event.Time.After(now.Add(24 * time.Hour)) {
+				event.Time = event.Time.AddDate(-1, 0, 0)
+			}
+		}
+	}
+	return event, true
+}
+
+// CountPullsByImage 풀 이벤트를 정규화된 레지스트리/저장소 이름별로 집계
+func CountPullsByImage(pullEvents []string) map[string]int {
+	counts := make(map[string]int)
+	for _, event := range pullEvents {
+		image := extractPulledImage(event)
+		if image == "" {
+			continue
+		}
+		ref, err := registry.ParseReference(image)
+		if err != nil {
+			continue
+		}
+		counts[ref.Name()]++
+	}
+	return counts
+}
+
+// CountPullsByReference 풀 이벤트를 태그 또는 digest를 포함한 정규화된 전체 참조별로 집계
+func CountPullsByReference(pullEvents []string) map[string]int {
+	counts := make(map[string]int)
+	for _, event := range pullEvents {
+		image := extractPulledImage(event)
+		if image == "" {
+			continue
+		}
+		ref, err := registry.ParseReference(image)
+		if err != nil {
+			continue
+		}
+		counts[ref.String()]++
+	}
+	return counts
+}
+
+// CountPullsByRegistry 풀 이벤트를 정규화된 레지스트리 호스트별로 집계
+func CountPullsByRegistry(pullEvents []string) map[string]int {
+	counts := make(map[string]int)
+	for _, event := range pullEvents {
+		image := extractPulledImage(event)
+		if image == "" {
+			continue
+		}
+		ref, err := registry.ParseReference(image)
+		if err != nil {
+			continue
+		}
+		counts[ref.Registry]++
+	}
+	return counts
+}
+
+// NoPullEventsError 지정된 시간 이후 로그에 풀 이벤트가 없음
+type NoPullEventsError struct {
+	Since string
+}
+
+func (e *NoPullEventsError) Error() string {
+	return fmt.Sprintf("no pull events found in logs since %s", e.Since)
+}
+
+// IsNoPullEvents 풀 이벤트가 없어서 발생한 오류인지 확인 (주기적으로 수집할 때는 빈 결과로 처리)
+func IsNoPullEvents(err error) bool {
+	var noEvents *NoPullEventsError
+	return errors.As(err, &noEvents)
 }
 
 // GetPullEvents 지정된 시간 이후의 풀 이벤트를 조회
@@ -81,82 +195,289 @@ func GetPullEvents(since string) ([]string, error) {
 	}
 	lines := strings.Split(string(out), "\n")
 	if len(lines) == 1 && lines[0] == "" {
-		return nil, fmt.Errorf("no pull events found in logs since %s", since)
+		return nil, &NoPullEventsError{Since: since}
 	}
 	return lines, nil
 }
 
-// PrintImagePullStatistics 이미지 풀 통계 출력
-func PrintImagePullStatistics(clientset *kubernetes.Clientset, pullEvents []string, since int) error {
-	// 현재 클러스터에서 사용 중인 이미지 목록 조회
-	clusterImages, err := GetPodImages(clientset)
-	//fmt.Println("<><>", clusterImages)
-	if err != nil {
-		return fmt.Errorf("failed to get cluster images: %v", err)
+// PullStatisticsOptions 이미지 풀 통계 출력 옵션
+type PullStatisticsOptions struct {
+	// Since 통계 기간 (시간)
+	Since int
+	// Sizes 이미지별 크기 (비어 있으면 전송량을 계산하지 않음)
+	Sizes ImageSizes
+	// CostPerGB 레지스트리별 GB(10^9 바이트)당 egress 비용, "default" 키는 나머지 레지스트리에 적용
+	CostPerGB map[string]float64
+}
+
+// costPerGB 레지스트리에 적용할 GB당 비용
+func (o PullStatisticsOptions) costPerGB(registryHost string) float64 {
+	if cost, ok := o.CostPerGB[registryHost]; ok {
+		return cost
 	}
+	return o.CostPerGB["default"]
+}
 
-	// 이미지별 풀 횟수 계산
-	imageCounts := make(map[string]int)
-	for _, event := range pullEvents {
-		imageName := extractImageName(event)
-		if imageName != "" {
-			imageCounts[imageName]++
+// ImagePullStat 이미지 하나의 풀 통계 (JSON 필드 이름은 출력 스키마이므로 변경하지 않음)
+type ImagePullStat struct {
+	Image    string `json:"image"`
+	Registry string `json:"registry"`
+	Pulls    int    `json:"pulls"`
+	InUse    bool   `json:"inUse"`
+	// SizeBytes 이미지 크기 (모르면 0)
+	SizeBytes  int64  `json:"sizeBytes"`
+	SizeSource string `json:"sizeSource,omitempty"`
+	// SizeCompressed 크기가 레지스트리 매니페스트의 압축 레이어 합계인지 여부
+	// (false면 노드/kubelet의 압축 해제 크기이므로 BytesPulled와 비용은 실제 egress의 상한)
+	SizeCompressed bool `json:"sizeCompressed"`
+	// BytesPulled 풀 횟수 × 이미지 크기
+	BytesPulled   int64   `json:"bytesPulled"`
+	EstimatedCost float64 `json:"estimatedCost"`
+}
+
+// RegistryPullStat 레지스트리별 전송량과 비용 합계
+type RegistryPullStat struct {
+	Registry      string  `json:"registry"`
+	Pulls         int     `json:"pulls"`
+	BytesPulled   int64   `json:"bytesPulled"`
+	EstimatedCost float64 `json:"estimatedCost"`
+}
+
+// PullSummary 풀 통계 요약
+type PullSummary struct {
+	TotalPulls        int     `json:"totalPulls"`
+	UniqueImages      int     `json:"uniqueImages"`
+	ActiveImages      int     `json:"activeImages"`
+	BytesPulled       int64   `json:"bytesPulled"`
+	EstimatedCost     float64 `json:"estimatedCost"`
+	ImagesWithoutSize int     `json:"imagesWithoutSize"`
+	// ImagesUncompressed 압축 해제 크기로 전송량을 계산한 이미지 수 (0보다 크면 BytesPulled는 상한)
+	ImagesUncompressed int                `json:"imagesUncompressed"`
+	Registries         []RegistryPullStat `json:"registries"`
+}
+
+// PullStatistics 기간 동안의 이미지 풀 통계 (수집 결과, 출력 형식과 무관)
+type PullStatistics struct {
+	PeriodHours int             `json:"periodHours"`
+	Images      []ImagePullStat `json:"images"`
+	Summary     PullSummary     `json:"summary"`
+	// CostEnabled egress 비용이 설정되었는지 여부 (표 출력에서 비용 줄 표시 여부)
+	CostEnabled bool `json:"-"`
+}
+
+// NewPullStatistics 풀 이벤트와 사용 중인 이미지 목록으로 풀 통계를 계산
+func NewPullStatistics(pullEvents []string, clusterImages []string, opts PullStatisticsOptions) *PullStatistics {
+	inUse := make(map[string]bool)
+	for _, image := range clusterImages {
+		if ref, err := registry.ParseReference(image); err == nil {
+			inUse[ref.Name()] = true
 		}
 	}
 
-	// 정렬을 위해 슬라이스로 변환
-	var imagePullCounts []struct {
-		Name  string
-		Count int
+	stats := &PullStatistics{PeriodHours: opts.Since, Images: []ImagePullStat{}, CostEnabled: len(opts.CostPerGB) > 0}
+	for name, count := range CountPullsByImage(pullEvents) {
+		ref, _ := registry.ParseReference(name)
+		size := opts.Sizes[name]
+		stat := ImagePullStat{
+			Image:          name,
+			Registry:       ref.Registry,
+			Pulls:          count,
+			InUse:          inUse[name],
+			SizeBytes:      size.Bytes,
+			SizeSource:     size.Source,
+			SizeCompressed: size.Source == SizeSourceRegistry,
+		}
+		stat.BytesPulled = stat.SizeBytes * int64(count)
+		stat.EstimatedCost = float64(stat.BytesPulled) / 1e9 * opts.costPerGB(ref.Registry)
+		stats.Images = append(stats.Images, stat)
+	}
+	sort.Slice(stats.Images, func(i, j int) bool {
+		if stats.Images[i].Pulls != stats.Images[j].Pulls {
+			return stats.Images[i].Pulls > stats.Images[j].Pulls
+		}
+		return stats.Images[i].Image < stats.Images[j].Image
+	})
+
+	// 요약 계산
+	summary := &stats.Summary
+	summary.UniqueImages = len(stats.Images)
+	summary.Registries = []RegistryPullStat{}
+	registries := make(map[string]*RegistryPullStat)
+	for _, img := range stats.Images {
+		summary.TotalPulls += img.Pulls
+		summary.BytesPulled += img.BytesPulled
+		summary.EstimatedCost += img.EstimatedCost
+		if img.InUse {
+			summary.ActiveImages++
+		}
+		if img.SizeBytes == 0 {
+			summary.ImagesWithoutSize++
+		} else if !img.SizeCompressed {
+			summary.ImagesUncompressed++
+		}
+		r, ok := registries[img.Registry]
+		if !ok {
+			r = &RegistryPullStat{Registry: img.Registry}
+			registries[img.Registry] = r
+		}
+		r.Pulls += img.Pulls
+		r.BytesPulled += img.BytesPulled
+		r.EstimatedCost += img.EstimatedCost
 	}
-	for name, count := range imageCounts {
-		imagePullCounts = append(imagePullCounts, struct {
-			Name  string
-			Count int
-		}{Name: name, Count: count})
+	for _, r := range registries {
+		summary.Registries = append(summary.Registries, *r)
 	}
-	sort.Slice(imagePullCounts, func(i, j int) bool {
-		return imagePullCounts[i].Count > imagePullCounts[j].Count
+	sort.Slice(summary.Registries, func(i, j int) bool {
+		if summary.Registries[i].BytesPulled != summary.Registries[j].BytesPulled {
+			return summary.Registries[i].BytesPulled > summary.Registries[j].BytesPulled
+		}
+		if summary.Registries[i].Pulls != summary.Registries[j].Pulls {
+			return summary.Registries[i].Pulls > summary.Registries[j].Pulls
+		}
+		return summary.Registries[i].Registry < summary.Registries[j].Registry
 	})
+	return stats
+}
+
+// Table 이미지별 풀 통계를 CSV/Markdown 표로 변환
+func (s *PullStatistics) Table() output.Table {
+	table := output.Table{
+		Title:  fmt.Sprintf("Image Pull Statistics (Last %d hours)", s.PeriodHours),
+		Header: []string{"image", "registry", "pulls", "in_use", "size_bytes", "size_source", "size_compressed", "bytes_pulled", "estimated_cost"},
+	}
+	for _, img := range s.Images {
+		table.Rows = append(table.Rows, []string{
+			img.Image,
+			img.Registry,
+			strconv.Itoa(img.Pulls),
+			strconv.FormatBool(img.InUse),
+			strconv.FormatInt(img.SizeBytes, 10),
+			img.SizeSource,
+			strconv.FormatBool(img.SizeCompressed),
+			strconv.FormatInt(img.BytesPulled, 10),
+			strconv.FormatFloat(img.EstimatedCost, 'f', 2, 64),
+		})
+	}
+	return table
+}
 
-	// 표 형식으로 출력
+// PrintImagePullStatistics 이미지 풀 통계를 표로 출력 (이미지 크기가 있으면 전송량과 egress 비용 추정 포함)
+func PrintImagePullStatistics(stats *PullStatistics) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
-	fmt.Fprintf(w, "\nImage Pull Statistics (Last %d hours):\n", since)
+	fmt.Fprintf(w, "\nImage Pull Statistics (Last %d hours):\n", stats.PeriodHours)
 	fmt.Fprintln(w, "=======================================================================")
-	fmt.Fprintln(w, "No.\tImage Name\tPull Count\tIn Use")
+	fmt.Fprintln(w, "No.\tImage Name\tPull Count\tIn Use\tImage Size\tPulls × Size\tEst. Cost")
 	fmt.Fprintln(w, "-----------------------------------------------------------------------")
 
-	for i, img := range imagePullCounts {
-		inUse := "No"
-		cleanName := cleanImageName(img.Name)
-		for _, clusterImg := range clusterImages {
-			if cleanImageName(clusterImg) == cleanName {
-				inUse = "Yes"
+	for i, img := range stats.Images {
+		fmt.Fprintf(w, "%d\t%s\t%d\t%s\t%s\t%s\t%s\n", i+1, img.Image, img.Pulls, yesNo(img.InUse),
+			formatSize(img), formatTotalBytes(img), formatCost(img.EstimatedCost, img.BytesPulled))
+	}
+	w.Flush()
+
+	// 전송량 기준 상위 이미지
+	ranked := append([]ImagePullStat{}, stats.Images...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].BytesPulled > ranked[j].BytesPulled
+	})
+	if len(ranked) > 0 && ranked[0].BytesPulled > 0 {
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
+		fmt.Fprintf(w, "\nTop Images by Pulls × Size:\n")
+		fmt.Fprintln(w, "Rank\tImage Name\tPulls × Size\tEst. Cost")
+		fmt.Fprintln(w, "-----------------------------------------------------------------------")
+		for i, img := range ranked {
+			if i == 10 || img.BytesPulled == 0 {
 				break
 			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", i+1, img.Image, units.FormatBytes(img.BytesPulled), formatCost(img.EstimatedCost, img.BytesPulled))
 		}
-		fmt.Fprintf(w, "%d\t%s\t%d\t%s\n", i+1, img.Name, img.Count, inUse)
+		w.Flush()
 	}
-	w.Flush()
 
 	// 요약 정보 출력
-	var totalPulls int
-	var activeImages int
-	for _, img := range imagePullCounts {
-		totalPulls += img.Count
-		cleanName := cleanImageName(img.Name)
-		for _, clusterImg := range clusterImages {
-			if cleanImageName(clusterImg) == cleanName {
-				activeImages++
-				break
+	summary := stats.Summary
+	fmt.Printf("\nSummary:\n")
+	fmt.Printf("- Period: Last %d hours\n", stats.PeriodHours)
+	fmt.Printf("- Total pull events: %d\n", summary.TotalPulls)
+	fmt.Printf("- Unique images with pulls: %d\n", summary.UniqueImages)
+	fmt.Printf("- Currently active images: %d\n", summary.ActiveImages)
+	if summary.BytesPulled > 0 {
+		if summary.ImagesUncompressed > 0 {
+			// 압축 해제 크기는 레지스트리에서 실제로 받은 압축 레이어보다 크므로 상한으로 표시
+			fmt.Printf("- Pulls × image size: %s (upper bound: %d images use uncompressed node/kubelet sizes; --registry-sizes uses compressed layer sizes)\n",
+				units.FormatBytes(summary.BytesPulled), summary.ImagesUncompressed)
+		} else {
+			fmt.Printf("- Estimated data pulled (compressed layers): %s\n", units.FormatBytes(summary.BytesPulled))
+		}
+		if stats.CostEnabled {
+			fmt.Printf("- Estimated egress cost: $%.2f%s\n", summary.EstimatedCost, upperBound(summary.ImagesUncompressed > 0))
+		}
+		for _, r := range summary.Registries {
+			if r.BytesPulled == 0 {
+				continue
 			}
+			fmt.Printf("  - %s: %s ($%.2f)\n", r.Registry, units.FormatBytes(r.BytesPulled), r.EstimatedCost)
+		}
+		if summary.ImagesWithoutSize > 0 {
+			fmt.Printf("- Images without size information: %d\n", summary.ImagesWithoutSize)
 		}
 	}
-	fmt.Printf("\nSummary:\n")
-	fmt.Printf("- Period: Last %d hours\n", since)
-	fmt.Printf("- Total pull events: %d\n", totalPulls)
-	fmt.Printf("- Unique images with pulls: %d\n", len(imagePullCounts))
-	fmt.Printf("- Currently active images: %d\n", activeImages)
+}
+
+// yesNo bool 값을 Yes/No로 표시
+func yesNo(value bool) string {
+	if value {
+		return "Yes"
+	}
+	return "No"
+}
+
+// upperBound 압축 해제 크기 기반 추정이면 상한임을 표시
+func upperBound(uncompressed bool) string {
+	if uncompressed {
+		return " (upper bound)"
+	}
+	return ""
+}
+
+// formatSize 이미지 크기와 출처를 표시 (모르면 "-")
+func formatSize(stat ImagePullStat) string {
+	if stat.SizeBytes == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%s (%s)", units.FormatBytes(stat.SizeBytes), stat.SizeSource)
+}
 
-	return nil
+// formatTotalBytes 풀 횟수 × 이미지 크기를 표시 (크기를 모르면 "-", 압축 해제 크기 기반이면 상한 "≤")
+func formatTotalBytes(stat ImagePullStat) string {
+	if stat.SizeBytes == 0 {
+		return "-"
+	}
+	if !stat.SizeCompressed {
+		return "≤ " + units.FormatBytes(stat.BytesPulled)
+	}
+	return units.FormatBytes(stat.BytesPulled)
+}
+
+// formatCost 추정 비용을 표시 (전송량을 모르면 "-")
+func formatCost(cost float64, totalBytes int64) string {
+	if totalBytes == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("$%.2f", cost)
+}
+
+// LatestPulledReferences 이미지 이름별로 가장 최근 풀 이벤트의 전체 참조(태그 또는 digest 포함)를 반환
+func LatestPulledReferences(pullEvents []string) map[string]registry.Reference {
+	refs := make(map[string]registry.Reference)
+	for _, event := range pullEvents {
+		image := extractPulledImage(event)
+		if image == "" {
+			continue
+		}
+		if ref, err := registry.ParseReference(image); err == nil {
+			refs[ref.Name()] = ref
+		}
+	}
+	return refs
 }
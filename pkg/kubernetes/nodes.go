@@ -0,0 +1,185 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// NodePlatform 노드의 OS/아키텍처 정보
+type NodePlatform struct {
+	Name         string
+	OS           string
+	Architecture string
+	Labels       map[string]string
+	// Taints 노드 taint (NoSchedule/NoExecute는 Pod가 허용해야 실행 가능)
+	Taints []corev1.Taint
+}
+
+// Platform 노드 플랫폼을 "os/arch" 형식으로 반환
+func (n NodePlatform) Platform() string {
+	return n.OS + "/" + n.Architecture
+}
+
+// GetNodePlatforms 모든 노드의 status.nodeInfo에서 OS와 아키텍처를 조회
+func GetNodePlatforms(clientset *kubernetes.Clientset) ([]NodePlatform, error) {
+	nodes, err := clientset.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %v", err)
+	}
+
+	platforms := make([]NodePlatform, 0, len(nodes.Items))
+	for _, node := range nodes.Items {
+		platforms = append(platforms, NodePlatform{
+			Name:         node.Name,
+			OS:           node.Status.NodeInfo.OperatingSystem,
+			Architecture: node.Status.NodeInfo.Architecture,
+			Labels:       node.Labels,
+			Taints:       node.Spec.Taints,
+		})
+	}
+	return platforms, nil
+}
+
+// PodPlacement Pod가 실행 중이거나 실행될 수 있는 노드 정보
+type PodPlacement struct {
+	Namespace string
+	Pod       string
+	Workload  string
+	// Node 스케줄된 노드 (Pending이면 빈 문자열)
+	Node   string
+	Images []string
+	// EligibleNodes nodeSelector, 필수 node affinity, taint/toleration을 만족하는 노드
+	EligibleNodes []string
+}
+
+// GetPodPlacements 네임스페이스(빈 문자열이면 전체)의 Pod마다 스케줄된 노드와 실행 가능한 노드를 계산
+func GetPodPlacements(clientset *kubernetes.Clientset, namespace string, nodes []NodePlatform) ([]PodPlacement, error) {
+	pods, err := clientset.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %v", err)
+	}
+
+	var placements []PodPlacement
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		placement := PodPlacement{
+			Namespace: pod.Namespace,
+			Pod:       pod.Name,
+			Workload:  podWorkload(pod),
+			Node:      pod.Spec.NodeName,
+			Images:    podContainerImages(pod),
+		}
+		for _, node := range nodes {
+			if podMatchesNode(pod, node) {
+				placement.EligibleNodes = append(placement.EligibleNodes, node.Name)
+			}
+		}
+		placements = append(placements, placement)
+	}
+	return placements, nil
+}
+
+// podMatchesNode Pod의 nodeSelector와 필수 node affinity가 노드와 일치하고 노드의 taint를 모두 허용하는지 확인
+func podMatchesNode(pod *corev1.Pod, node NodePlatform) bool {
+	if !toleratesTaints(pod.Spec.Tolerations, node.Taints) {
+		return false
+	}
+	for key, value := range pod.Spec.NodeSelector {
+		if node.Labels[key] != value {
+			return false
+		}
+	}
+
+	affinity := pod.Spec.Affinity
+	if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return true
+	}
+	// 선택 조건은 OR, 조건 안의 표현식은 AND
+	for _, term := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		if nodeSelectorTermMatches(term, node) {
+			return true
+		}
+	}
+	return false
+}
+
+// toleratesTaints 스케줄을 막는 taint(NoSchedule, NoExecute)를 toleration이 모두 허용하는지 확인 (PreferNoSchedule은 무시)
+func toleratesTaints(tolerations []corev1.Toleration, taints []corev1.Taint) bool {
+	for i := range taints {
+		taint := &taints[i]
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		tolerated := false
+		for j := range tolerations {
+			if tolerations[j].ToleratesTaint(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+	return true
+}
+
+// nodeSelectorTermMatches 노드 선택 조건 하나가 노드 레이블 및 이름과 일치하는지 확인
+func nodeSelectorTermMatches(term corev1.NodeSelectorTerm, node NodePlatform) bool {
+	if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+		return false
+	}
+	for _, expr := range term.MatchExpressions {
+		value, exists := node.Labels[expr.Key]
+		if !requirementMatches(expr, value, exists) {
+			return false
+		}
+	}
+	for _, expr := range term.MatchFields {
+		if expr.Key == "metadata.name" && !requirementMatches(expr, node.Name, true) {
+			return false
+		}
+	}
+	return true
+}
+
+// requirementMatches 노드 선택 표현식 하나를 평가
+// Gt/Lt는 스케줄러와 같이 레이블 값과 하나뿐인 Values를 정수로 비교 (정수가 아니면 불일치)
+func requirementMatches(expr corev1.NodeSelectorRequirement, value string, exists bool) bool {
+	switch expr.Operator {
+	case corev1.NodeSelectorOpIn:
+		return exists && slices.Contains(expr.Values, value)
+	case corev1.NodeSelectorOpNotIn:
+		return !exists || !slices.Contains(expr.Values, value)
+	case corev1.NodeSelectorOpExists:
+		return exists
+	case corev1.NodeSelectorOpDoesNotExist:
+		return !exists
+	case corev1.NodeSelectorOpGt, corev1.NodeSelectorOpLt:
+		if !exists || len(expr.Values) != 1 {
+			return false
+		}
+		actual, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false
+		}
+		expected, err := strconv.ParseInt(expr.Values[0], 10, 64)
+		if err != nil {
+			return false
+		}
+		if expr.Operator == corev1.NodeSelectorOpGt {
+			return actual > expected
+		}
+		return actual < expected
+	}
+	return false
+}
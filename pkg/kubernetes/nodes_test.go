@@ -0,0 +1,135 @@
+package kubernetes
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func requiredAffinity(terms ...corev1.NodeSelectorTerm) *corev1.Affinity {
+	return &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: terms},
+		},
+	}
+}
+
+func expression(key string, op corev1.NodeSelectorOperator, values ...string) corev1.NodeSelectorTerm {
+	return corev1.NodeSelectorTerm{
+		MatchExpressions: []corev1.NodeSelectorRequirement{{Key: key, Operator: op, Values: values}},
+	}
+}
+
+func TestPodMatchesNode(t *testing.T) {
+	worker := NodePlatform{
+		Name:         "worker-1",
+		OS:           "linux",
+		Architecture: "arm64",
+		Labels: map[string]string{
+			"kubernetes.io/arch": "arm64",
+			"kubernetes.io/os":   "linux",
+			"disktype":           "ssd",
+			"cpu-count":          "8",
+			"generation":         "v2",
+		},
+	}
+	controlPlane := NodePlatform{
+		Name:   "cp-1",
+		Labels: map[string]string{"kubernetes.io/arch": "amd64", "node-role.kubernetes.io/control-plane": ""},
+		Taints: []corev1.Taint{{Key: "node-role.kubernetes.io/control-plane", Effect: corev1.TaintEffectNoSchedule}},
+	}
+	gpu := NodePlatform{
+		Name:   "gpu-1",
+		Labels: map[string]string{"kubernetes.io/arch": "amd64"},
+		Taints: []corev1.Taint{
+			{Key: "nvidia.com/gpu", Value: "present", Effect: corev1.TaintEffectNoExecute},
+			{Key: "maintenance", Effect: corev1.TaintEffectPreferNoSchedule},
+		},
+	}
+
+	tests := []struct {
+		name string
+		spec corev1.PodSpec
+		node NodePlatform
+		want bool
+	}{
+		{"no constraints", corev1.PodSpec{}, worker, true},
+
+		// nodeSelector
+		{"nodeSelector match", corev1.PodSpec{NodeSelector: map[string]string{"kubernetes.io/arch": "arm64"}}, worker, true},
+		{"nodeSelector mismatch", corev1.PodSpec{NodeSelector: map[string]string{"kubernetes.io/arch": "amd64"}}, worker, false},
+		{"nodeSelector missing label", corev1.PodSpec{NodeSelector: map[string]string{"zone": "a"}}, worker, false},
+
+		// 필수 node affinity
+		{"In match", corev1.PodSpec{Affinity: requiredAffinity(expression("disktype", corev1.NodeSelectorOpIn, "hdd", "ssd"))}, worker, true},
+		{"In mismatch", corev1.PodSpec{Affinity: requiredAffinity(expression("disktype", corev1.NodeSelectorOpIn, "hdd"))}, worker, false},
+		{"In missing label", corev1.PodSpec{Affinity: requiredAffinity(expression("zone", corev1.NodeSelectorOpIn, "a"))}, worker, false},
+		{"NotIn match", corev1.PodSpec{Affinity: requiredAffinity(expression("disktype", corev1.NodeSelectorOpNotIn, "hdd"))}, worker, true},
+		{"NotIn mismatch", corev1.PodSpec{Affinity: requiredAffinity(expression("disktype", corev1.NodeSelectorOpNotIn, "ssd"))}, worker, false},
+		{"NotIn missing label", corev1.PodSpec{Affinity: requiredAffinity(expression("zone", corev1.NodeSelectorOpNotIn, "a"))}, worker, true},
+		{"Exists", corev1.PodSpec{Affinity: requiredAffinity(expression("disktype", corev1.NodeSelectorOpExists))}, worker, true},
+		{"Exists missing label", corev1.PodSpec{Affinity: requiredAffinity(expression("zone", corev1.NodeSelectorOpExists))}, worker, false},
+		{"DoesNotExist", corev1.PodSpec{Affinity: requiredAffinity(expression("zone", corev1.NodeSelectorOpDoesNotExist))}, worker, true},
+		{"DoesNotExist present label", corev1.PodSpec{Affinity: requiredAffinity(expression("disktype", corev1.NodeSelectorOpDoesNotExist))}, worker, false},
+		{"Gt match", corev1.PodSpec{Affinity: requiredAffinity(expression("cpu-count", corev1.NodeSelectorOpGt, "4"))}, worker, true},
+		{"Gt equal", corev1.PodSpec{Affinity: requiredAffinity(expression("cpu-count", corev1.NodeSelectorOpGt, "8"))}, worker, false},
+		{"Gt compares integers not strings", corev1.PodSpec{Affinity: requiredAffinity(expression("cpu-count", corev1.NodeSelectorOpGt, "10"))}, worker, false},
+		{"Lt match", corev1.PodSpec{Affinity: requiredAffinity(expression("cpu-count", corev1.NodeSelectorOpLt, "16"))}, worker, true},
+		{"Lt mismatch", corev1.PodSpec{Affinity: requiredAffinity(expression("cpu-count", corev1.NodeSelectorOpLt, "2"))}, worker, false},
+		{"Gt non-integer label", corev1.PodSpec{Affinity: requiredAffinity(expression("generation", corev1.NodeSelectorOpGt, "1"))}, worker, false},
+		{"Gt non-integer value", corev1.PodSpec{Affinity: requiredAffinity(expression("cpu-count", corev1.NodeSelectorOpGt, "four"))}, worker, false},
+		{"Gt missing label", corev1.PodSpec{Affinity: requiredAffinity(expression("zone", corev1.NodeSelectorOpGt, "1"))}, worker, false},
+		{"Lt multiple values", corev1.PodSpec{Affinity: requiredAffinity(expression("cpu-count", corev1.NodeSelectorOpLt, "16", "32"))}, worker, false},
+		{"expressions in a term are ANDed", corev1.PodSpec{Affinity: requiredAffinity(corev1.NodeSelectorTerm{
+			MatchExpressions: []corev1.NodeSelectorRequirement{
+				{Key: "disktype", Operator: corev1.NodeSelectorOpIn, Values: []string{"ssd"}},
+				{Key: "kubernetes.io/arch", Operator: corev1.NodeSelectorOpIn, Values: []string{"amd64"}},
+			},
+		})}, worker, false},
+		{"terms are ORed", corev1.PodSpec{Affinity: requiredAffinity(
+			expression("kubernetes.io/arch", corev1.NodeSelectorOpIn, "amd64"),
+			expression("disktype", corev1.NodeSelectorOpIn, "ssd"),
+		)}, worker, true},
+		{"empty term matches nothing", corev1.PodSpec{Affinity: requiredAffinity(corev1.NodeSelectorTerm{})}, worker, false},
+		{"matchFields node name", corev1.PodSpec{Affinity: requiredAffinity(corev1.NodeSelectorTerm{
+			MatchFields: []corev1.NodeSelectorRequirement{{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{"worker-1"}}},
+		})}, worker, true},
+		{"matchFields other node", corev1.PodSpec{Affinity: requiredAffinity(corev1.NodeSelectorTerm{
+			MatchFields: []corev1.NodeSelectorRequirement{{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{"worker-2"}}},
+		})}, worker, false},
+		{"nodeSelector and affinity both apply", corev1.PodSpec{
+			NodeSelector: map[string]string{"disktype": "hdd"},
+			Affinity:     requiredAffinity(expression("kubernetes.io/arch", corev1.NodeSelectorOpIn, "arm64")),
+		}, worker, false},
+
+		// taint/toleration
+		{"control-plane taint not tolerated", corev1.PodSpec{}, controlPlane, false},
+		{"control-plane taint tolerated by key", corev1.PodSpec{Tolerations: []corev1.Toleration{
+			{Key: "node-role.kubernetes.io/control-plane", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+		}}, controlPlane, true},
+		{"toleration with other effect", corev1.PodSpec{Tolerations: []corev1.Toleration{
+			{Key: "node-role.kubernetes.io/control-plane", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute},
+		}}, controlPlane, false},
+		{"tolerate everything", corev1.PodSpec{Tolerations: []corev1.Toleration{{Operator: corev1.TolerationOpExists}}}, controlPlane, true},
+		{"NoExecute taint not tolerated", corev1.PodSpec{}, gpu, false},
+		{"NoExecute taint tolerated by value", corev1.PodSpec{Tolerations: []corev1.Toleration{
+			{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpEqual, Value: "present"},
+		}}, gpu, true},
+		{"NoExecute taint with wrong value", corev1.PodSpec{Tolerations: []corev1.Toleration{
+			{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpEqual, Value: "absent"},
+		}}, gpu, false},
+		{"toleration does not bypass affinity", corev1.PodSpec{
+			Tolerations: []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+			Affinity:    requiredAffinity(expression("kubernetes.io/arch", corev1.NodeSelectorOpIn, "arm64")),
+		}, controlPlane, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{Spec: tt.spec}
+			if got := podMatchesNode(pod, tt.node); got != tt.want {
+				t.Errorf("podMatchesNode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
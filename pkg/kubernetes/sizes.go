@@ -0,0 +1,84 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// 이미지 크기 출처 (우선순위 순)
+const (
+	SizeSourceRegistry = "registry"
+	SizeSourceEvent    = "event"
+	SizeSourceNode     = "node"
+)
+
+// sizeSourcePriority 출처별 우선순위 (클수록 우선, 레지스트리는 실제 전송량인 압축 크기)
+var sizeSourcePriority = map[string]int{
+	SizeSourceNode:     1,
+	SizeSourceEvent:    2,
+	SizeSourceRegistry: 3,
+}
+
+// ImageSize 이미지 하나의 크기와 출처
+type ImageSize struct {
+	Bytes  int64
+	Source string
+}
+
+// ImageSizes 레지스트리/저장소 이름별 이미지 크기
+type ImageSizes map[string]ImageSize
+
+// Add 우선순위가 높은 출처의 크기를 유지하고, 같은 출처면 더 큰 값을 유지 (보수적 추정)
+func (s ImageSizes) Add(image string, bytes int64, source string) {
+	if bytes <= 0 {
+		return
+	}
+	ref, err := registry.ParseReference(image)
+	if err != nil {
+		return
+	}
+	name := ref.Name()
+
+	current, ok := s[name]
+	if ok {
+		if sizeSourcePriority[current.Source] > sizeSourcePriority[source] {
+			return
+		}
+		if current.Source == source && current.Bytes >= bytes {
+			return
+		}
+	}
+	s[name] = ImageSize{Bytes: bytes, Source: source}
+}
+
+// GetImageSizes kubelet "Image size" 이벤트와 node.status.images에서 이미지 크기를 수집
+func GetImageSizes(clientset *kubernetes.Clientset, since time.Time) (ImageSizes, error) {
+	sizes := make(ImageSizes)
+
+	nodes, err := clientset.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %v", err)
+	}
+	for _, node := range nodes.Items {
+		for _, image := range node.Status.Images {
+			for _, name := range image.Names {
+				sizes.Add(name, image.SizeBytes, SizeSourceNode)
+			}
+		}
+	}
+
+	events, err := GetKubeletPullEvents(clientset, since)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		sizes.Add(event.Image, event.SizeBytes, SizeSourceEvent)
+	}
+
+	return sizes, nil
+}
@@ -0,0 +1,80 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// podWorkload Pod를 소유한 워크로드를 "Kind/Name" 형식으로 반환
+func podWorkload(pod *corev1.Pod) string {
+	for _, owner := range pod.OwnerReferences {
+		if owner.Controller == nil || !*owner.Controller {
+			continue
+		}
+		// Deployment가 생성한 ReplicaSet은 pod-template-hash를 제거해 Deployment 이름으로 표시
+		if owner.Kind == "ReplicaSet" {
+			if hash := pod.Labels["pod-template-hash"]; hash != "" && strings.HasSuffix(owner.Name, "-"+hash) {
+				return "Deployment/" + strings.TrimSuffix(owner.Name, "-"+hash)
+			}
+		}
+		return owner.Kind + "/" + owner.Name
+	}
+	return "Pod/" + pod.Name
+}
+
+// podContainerImages Pod의 일반 컨테이너와 Init 컨테이너 이미지를 모두 반환
+func podContainerImages(pod *corev1.Pod) []string {
+	var images []string
+	for _, container := range pod.Spec.Containers {
+		images = append(images, container.Image)
+	}
+	for _, container := range pod.Spec.InitContainers {
+		images = append(images, container.Image)
+	}
+	return images
+}
+
+// WorkloadImage 워크로드가 Pod 스펙에서 사용하는 이미지
+type WorkloadImage struct {
+	Namespace string
+	Workload  string
+	Image     string
+	// Pods 이 이미지를 사용하는 Pod 수
+	Pods int
+}
+
+// GetWorkloadImages 네임스페이스(빈 문자열이면 전체)의 Pod 스펙 이미지를 워크로드별로 중복 없이 조회
+func GetWorkloadImages(clientset *kubernetes.Clientset, namespace string) ([]WorkloadImage, error) {
+	pods, err := clientset.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %v", err)
+	}
+
+	index := make(map[WorkloadImage]int)
+	var images []WorkloadImage
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		workload := podWorkload(pod)
+		seen := make(map[string]bool)
+		for _, image := range podContainerImages(pod) {
+			if seen[image] {
+				continue
+			}
+			seen[image] = true
+			key := WorkloadImage{Namespace: pod.Namespace, Workload: workload, Image: image}
+			if n, ok := index[key]; ok {
+				images[n].Pods++
+				continue
+			}
+			index[key] = len(images)
+			key.Pods = 1
+			images = append(images, key)
+		}
+	}
+	return images, nil
+}
@@ -0,0 +1,231 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Type 메트릭 종류
+type Type string
+
+// 지원하는 메트릭 종류
+const (
+	Counter   Type = "counter"
+	Gauge     Type = "gauge"
+	Histogram Type = "histogram"
+)
+
+// Exposition 형식의 Content-Type
+const (
+	TextContentType        = "text/plain; version=0.0.4; charset=utf-8"
+	OpenMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+)
+
+// Label 메트릭 레이블 (출력 순서를 유지하기 위해 슬라이스로 사용)
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Sample 레이블 조합 하나의 값 (Histogram 메트릭은 Histogram 사용)
+type Sample struct {
+	Labels    []Label
+	Value     float64
+	Histogram *HistogramValue
+}
+
+// Family 이름, 설명, 종류가 같은 메트릭 묶음 (Counter 이름은 "_total"로 끝남)
+type Family struct {
+	Name    string
+	Help    string
+	Type    Type
+	Samples []Sample
+}
+
+// Add 레이블 이름/값 쌍(name1, value1, name2, value2, ...)으로 값을 추가
+func (f *Family) Add(value float64, labels ...string) {
+	f.Samples = append(f.Samples, Sample{Labels: pairs(labels), Value: value})
+}
+
+// AddHistogram 레이블 이름/값 쌍으로 히스토그램을 추가
+func (f *Family) AddHistogram(histogram *HistogramValue, labels ...string) {
+	f.Samples = append(f.Samples, Sample{Labels: pairs(labels), Histogram: histogram})
+}
+
+// pairs 이름/값이 번갈아 나오는 문자열 목록을 레이블로 변환
+func pairs(labels []string) []Label {
+	result := make([]Label, 0, len(labels)/2)
+	for i := 0; i+1 < len(labels); i += 2 {
+		result = append(result, Label{Name: labels[i], Value: labels[i+1]})
+	}
+	return result
+}
+
+// HistogramValue 누적 버킷 히스토그램 (Counts[i]는 Buckets[i] 이하 값의 개수)
+type HistogramValue struct {
+	Buckets []float64
+	Counts  []uint64
+	Count   uint64
+	Sum     float64
+}
+
+// NewHistogram 오름차순 상한 값으로 빈 히스토그램을 생성 (+Inf 버킷은 Count로 표시)
+func NewHistogram(buckets []float64) *HistogramValue {
+	return &HistogramValue{Buckets: buckets, Counts: make([]uint64, len(buckets))}
+}
+
+// Observe 값 하나를 기록
+func (h *HistogramValue) Observe(value float64) {
+	for i, bound := range h.Buckets {
+		if value <= bound {
+			h.Counts[i]++
+		}
+	}
+	h.Count++
+	h.Sum += value
+}
+
+// Copy 수집 중에도 안전하게 출력할 수 있도록 복사본을 반환
+func (h *HistogramValue) Copy() *HistogramValue {
+	copied := *h
+	copied.Counts = append([]uint64(nil), h.Counts...)
+	return &copied
+}
+
+// WriteText 메트릭을 Prometheus text exposition 형식으로 출력 (샘플은 레이블 순으로 정렬)
+func WriteText(w io.Writer, families []Family) error {
+	var b strings.Builder
+	for _, family := range families {
+		if len(family.Samples) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "# HELP %s %s\n", family.Name, escapeHelp(family.Help))
+		fmt.Fprintf(&b, "# TYPE %s %s\n", family.Name, family.Type)
+		for _, sample := range sortedSamples(family.Samples) {
+			writeSample(&b, family.Name, sample)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteOpenMetrics 메트릭을 OpenMetrics text 형식으로 출력
+// Counter는 MetricFamily 이름에서 "_total"을 뺀 이름으로 선언하고 마지막에 "# EOF"를 붙임
+func WriteOpenMetrics(w io.Writer, families []Family) error {
+	var b strings.Builder
+	for _, family := range families {
+		if len(family.Samples) == 0 {
+			continue
+		}
+		name := family.Name
+		if family.Type == Counter {
+			name = strings.TrimSuffix(name, "_total")
+		}
+		fmt.Fprintf(&b, "# TYPE %s %s\n", name, family.Type)
+		fmt.Fprintf(&b, "# HELP %s %s\n", name, escapeLabelValue(family.Help))
+		for _, sample := range sortedSamples(family.Samples) {
+			writeSample(&b, family.Name, sample)
+		}
+	}
+	b.WriteString("# EOF\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteFile 메트릭을 OpenMetrics 형식으로 path에 원자적으로 기록
+// 같은 디렉터리의 임시 파일(".<이름>.tmp", node_exporter textfile collector가 읽지 않는 이름)에 쓴 뒤 rename하므로
+// 수집기가 쓰는 도중의 파일을 읽지 않음
+func WriteFile(path string, families []Family) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := WriteOpenMetrics(tmp, families); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %v", tmp.Name(), err)
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to chmod %s: %v", tmp.Name(), err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync %s: %v", tmp.Name(), err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %v", tmp.Name(), err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to rename %s to %s: %v", tmp.Name(), path, err)
+	}
+	return nil
+}
+
+// writeSample 샘플 하나를 출력 (히스토그램은 _bucket, _sum, _count 행)
+func writeSample(b *strings.Builder, name string, sample Sample) {
+	if sample.Histogram == nil {
+		fmt.Fprintf(b, "%s%s %s\n", name, formatLabels(sample.Labels), FormatValue(sample.Value))
+		return
+	}
+	histogram := sample.Histogram
+	for i, bound := range histogram.Buckets {
+		labels := append(append([]Label(nil), sample.Labels...), Label{Name: "le", Value: FormatValue(bound)})
+		fmt.Fprintf(b, "%s_bucket%s %d\n", name, formatLabels(labels), histogram.Counts[i])
+	}
+	labels := append(append([]Label(nil), sample.Labels...), Label{Name: "le", Value: "+Inf"})
+	fmt.Fprintf(b, "%s_bucket%s %d\n", name, formatLabels(labels), histogram.Count)
+	fmt.Fprintf(b, "%s_sum%s %s\n", name, formatLabels(sample.Labels), FormatValue(histogram.Sum))
+	fmt.Fprintf(b, "%s_count%s %d\n", name, formatLabels(sample.Labels), histogram.Count)
+}
+
+// sortedSamples 출력이 매번 같도록 레이블 문자열 순으로 정렬한 복사본
+func sortedSamples(samples []Sample) []Sample {
+	sorted := append([]Sample(nil), samples...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return formatLabels(sorted[i].Labels) < formatLabels(sorted[j].Labels)
+	})
+	return sorted
+}
+
+// formatLabels {name="value",...} 형식 (레이블이 없으면 빈 문자열)
+func formatLabels(labels []Label) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	parts := make([]string, len(labels))
+	for i, label := range labels {
+		parts[i] = label.Name + `="` + escapeLabelValue(label.Value) + `"`
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// FormatValue 메트릭 값을 exposition 형식의 숫자로 변환 (+Inf, -Inf, NaN 포함)
+func FormatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// escapeHelp HELP 문자열의 '\'와 줄바꿈을 이스케이프
+func escapeHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
+
+// escapeLabelValue 레이블 값의 '\', '"', 줄바꿈을 이스케이프
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
@@ -0,0 +1,380 @@
+package mirror
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// 미러링 결과 상태
+const (
+	StatusCopied   = "Copied"
+	StatusUpToDate = "Up-to-date"
+	StatusDryRun   = "Would copy"
+	StatusFailed   = "Failed"
+)
+
+// Image 미러링할 원본 이미지와 대상 참조
+type Image struct {
+	Source registry.Reference
+	Target registry.Reference
+	// Pods 이미지를 실행 중인 파드 수
+	Pods int
+}
+
+// Result 이미지 하나를 미러링한 결과 (DryRun이면 복사할 예정인 양)
+type Result struct {
+	Image
+	Status string
+	// Digest 복사한 매니페스트(또는 인덱스) digest
+	Digest       string
+	Manifests    int
+	BlobsCopied  int
+	BlobsMounted int
+	BlobsSkipped int
+	BytesCopied  int64
+	BytesSkipped int64
+	Error        string
+}
+
+// TargetReference 원본 참조를 대상 레지스트리 경로로 변환
+// target은 "host[/prefix]" 형식이며 저장소는 "<prefix>/<원본 레지스트리>/<원본 저장소>"가 됨
+// 저장소 이름에 ':'를 쓸 수 없으므로 원본 레지스트리의 포트 구분자는 '-'로 바꿈 (예: reg.local-5000)
+func TargetReference(target string, source registry.Reference) registry.Reference {
+	host, prefix, _ := strings.Cut(strings.Trim(target, "/"), "/")
+	repository := strings.ReplaceAll(source.Registry, ":", "-") + "/" + source.Repository
+	if prefix != "" {
+		repository = prefix + "/" + repository
+	}
+	return registry.Reference{Registry: registry.NormalizeRegistry(host), Repository: repository, Tag: source.Tag, Digest: source.Digest}
+}
+
+// InUseImages 실행 중인 컨테이너에서 미러링할 이미지를 digest 단위로 수집 (대상 레지스트리의 이미지는 제외)
+// 런타임이 매니페스트 digest를 알려주지 않으면 태그가 현재 가리키는 digest를 복사
+func InUseImages(containers []kubernetes.RunningContainer, target string) ([]Image, []string) {
+	targetHost := registry.NormalizeRegistry(strings.SplitN(strings.Trim(target, "/"), "/", 2)[0])
+
+	images := make(map[string]*Image)
+	var keys []string
+	var errs []string
+	for _, container := range containers {
+		ref, err := registry.ParseReference(container.Image)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s/%s: %v", container.Namespace, container.Pod, err))
+			continue
+		}
+		if ref.Registry == targetHost {
+			continue
+		}
+		if container.RepoDigest != "" {
+			ref.Digest = container.RepoDigest
+		}
+
+		key := ref.String()
+		if image, ok := images[key]; ok {
+			image.Pods++
+			continue
+		}
+		images[key] = &Image{Source: ref, Target: TargetReference(target, ref), Pods: 1}
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	result := make([]Image, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, *images[key])
+	}
+	return result, errs
+}
+
+// Mirror 원본 레지스트리에서 대상 레지스트리로 매니페스트와 blob을 digest 그대로 복사
+type Mirror struct {
+	Client *registry.Client
+	// Concurrency 동시에 복사할 이미지 수
+	Concurrency int
+	// DryRun 업로드 없이 복사할 blob과 크기만 계산
+	DryRun bool
+
+	mu sync.Mutex
+	// blobs 대상 레지스트리에 있는 것으로 확인된 blob ("<레지스트리>@<digest>" → 저장소), cross-repository mount에 사용
+	blobs map[string]map[string]bool
+	// sourceTags 원본 태그별 조회 결과 (같은 태그의 이미지가 여러 digest로 실행 중이어도 태그마다 한 번만 조회)
+	sourceTags map[string]*sourceTag
+
+	// tagMu 대상 태그 업로드를 직렬화
+	tagMu sync.Mutex
+	// targetTags 업로드한 대상 태그 → digest
+	targetTags map[string]string
+}
+
+// sourceTag 원본 태그가 가리키는 매니페스트
+type sourceTag struct {
+	once     sync.Once
+	manifest *registry.Manifest
+	err      error
+}
+
+// NewMirror 레지스트리 클라이언트로 Mirror를 생성 (concurrency가 1 미만이면 1)
+func NewMirror(client *registry.Client, concurrency int, dryRun bool) *Mirror {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &Mirror{
+		Client:      client,
+		Concurrency: concurrency,
+		DryRun:      dryRun,
+		blobs:       make(map[string]map[string]bool),
+		sourceTags:  make(map[string]*sourceTag),
+		targetTags:  make(map[string]string),
+	}
+}
+
+// Run 이미지들을 Concurrency 개씩 동시에 미러링하고 입력 순서대로 결과를 반환
+func (m *Mirror) Run(ctx context.Context, images []Image) []Result {
+	results := make([]Result, len(images))
+
+	semaphore := make(chan struct{}, m.Concurrency)
+	var wg sync.WaitGroup
+	for i, image := range images {
+		wg.Add(1)
+		go func(i int, image Image) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			results[i] = m.Copy(ctx, image)
+		}(i, image)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// Copy 이미지 하나를 모든 플랫폼과 함께 복사 (대상에 같은 digest가 있으면 태그만 갱신)
+// 실행 중인 digest가 원본 태그 인덱스의 플랫폼 매니페스트이면 인덱스 전체를 복사하고 태그가 인덱스를 가리키도록 함
+func (m *Mirror) Copy(ctx context.Context, image Image) Result {
+	result := Result{Image: image}
+
+	manifest, tagged, err := m.resolve(ctx, image.Source)
+	if err != nil {
+		result.Status, result.Error = StatusFailed, err.Error()
+		return result
+	}
+	result.Digest = manifest.Digest
+	target := image.Target.WithDigest(manifest.Digest)
+
+	if _, err := m.Client.HeadManifest(ctx, target); err == nil {
+		result.Status = StatusUpToDate
+	} else if !registry.IsNotFound(err) {
+		result.Status, result.Error = StatusFailed, err.Error()
+		return result
+	} else if err := m.copyManifest(ctx, image.Source.WithDigest(manifest.Digest), target, manifest, &result); err != nil {
+		result.Status, result.Error = StatusFailed, err.Error()
+		return result
+	}
+
+	// 원본 태그도 같은 digest를 가리키도록 설정
+	if tagged && !m.DryRun {
+		if err := m.putTag(ctx, image.Target.WithTag(image.Source.Tag), manifest); err != nil {
+			result.Status, result.Error = StatusFailed, err.Error()
+			return result
+		}
+	}
+
+	if result.Status == "" {
+		result.Status = StatusCopied
+		if m.DryRun {
+			result.Status = StatusDryRun
+		}
+	}
+	return result
+}
+
+// resolve 복사할 매니페스트를 결정하고 대상 태그를 갱신할지 반환
+// 태그가 있으면 태그가 현재 가리키는 매니페스트(보통 인덱스)를 사용
+// 태그가 이동하여 실행 중인 digest를 포함하지 않거나 태그가 삭제되었으면 실행 중인 digest만 복사하고 태그는 갱신하지 않음
+func (m *Mirror) resolve(ctx context.Context, source registry.Reference) (*registry.Manifest, bool, error) {
+	if source.Tag == "" {
+		manifest, err := m.Client.GetManifest(ctx, source)
+		return manifest, false, err
+	}
+
+	tagged, err := m.tagManifest(ctx, source.WithTag(source.Tag))
+	if err == nil && (source.Digest == "" || tagged.Digest == source.Digest || containsManifest(tagged, source.Digest)) {
+		return tagged, true, nil
+	}
+	if err != nil && (source.Digest == "" || !registry.IsNotFound(err)) {
+		return nil, false, err
+	}
+	manifest, err := m.Client.GetManifest(ctx, source.WithDigest(source.Digest))
+	return manifest, false, err
+}
+
+// tagManifest 원본 태그가 가리키는 매니페스트를 조회 (태그마다 한 번만 조회)
+func (m *Mirror) tagManifest(ctx context.Context, ref registry.Reference) (*registry.Manifest, error) {
+	m.mu.Lock()
+	entry, ok := m.sourceTags[ref.String()]
+	if !ok {
+		entry = &sourceTag{}
+		m.sourceTags[ref.String()] = entry
+	}
+	m.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.manifest, entry.err = m.Client.GetManifest(ctx, ref)
+	})
+	return entry.manifest, entry.err
+}
+
+// putTag 대상 태그가 매니페스트를 가리키도록 업로드 (같은 태그의 업로드는 직렬화하고 이미 같은 digest로 올렸으면 생략)
+func (m *Mirror) putTag(ctx context.Context, ref registry.Reference, manifest *registry.Manifest) error {
+	m.tagMu.Lock()
+	defer m.tagMu.Unlock()
+
+	key := ref.String()
+	if m.targetTags[key] == manifest.Digest {
+		return nil
+	}
+	if err := m.Client.PutManifest(ctx, ref, manifest.MediaType, manifest.Raw); err != nil {
+		return err
+	}
+	m.targetTags[key] = manifest.Digest
+	return nil
+}
+
+// containsManifest 인덱스가 digest의 하위 매니페스트를 포함하는지 확인
+func containsManifest(manifest *registry.Manifest, digest string) bool {
+	if !manifest.IsIndex() {
+		return false
+	}
+	for _, desc := range manifest.Manifests {
+		if desc.Digest == digest {
+			return true
+		}
+	}
+	return false
+}
+
+// copyManifest 인덱스면 하위 매니페스트를 먼저, 이미지 매니페스트면 config와 레이어를 먼저 복사한 뒤 매니페스트를 업로드
+func (m *Mirror) copyManifest(ctx context.Context, source, target registry.Reference, manifest *registry.Manifest, result *Result) error {
+	if manifest.IsIndex() {
+		// 인덱스 digest를 유지하려면 attestation을 포함한 모든 하위 매니페스트가 필요
+		for _, desc := range manifest.Manifests {
+			childTarget := target.WithDigest(desc.Digest)
+			if _, err := m.Client.HeadManifest(ctx, childTarget); err == nil {
+				continue
+			} else if !registry.IsNotFound(err) {
+				return err
+			}
+			child, err := m.Client.GetManifest(ctx, source.WithDigest(desc.Digest))
+			if err != nil {
+				return err
+			}
+			if err := m.copyManifest(ctx, source.WithDigest(desc.Digest), childTarget, child, result); err != nil {
+				return err
+			}
+		}
+	} else {
+		blobs := append([]registry.Descriptor{manifest.Config}, manifest.Layers...)
+		for _, desc := range blobs {
+			if desc.Digest == "" {
+				continue
+			}
+			if err := m.copyBlob(ctx, source, target, desc, result); err != nil {
+				return err
+			}
+		}
+	}
+
+	result.Manifests++
+	if m.DryRun {
+		return nil
+	}
+	return m.Client.PutManifest(ctx, target, manifest.MediaType, manifest.Raw)
+}
+
+// copyBlob 대상 저장소에 blob이 없으면 같은 레지스트리의 다른 저장소에서 mount하거나 원본에서 스트리밍 업로드
+func (m *Mirror) copyBlob(ctx context.Context, source, target registry.Reference, desc registry.Descriptor, result *Result) error {
+	mountFrom, known := m.knownBlob(target, desc.Digest)
+	if known && mountFrom == target.Repository {
+		result.BlobsSkipped++
+		result.BytesSkipped += desc.Size
+		return nil
+	}
+
+	exists, err := m.Client.BlobExists(ctx, target, desc.Digest)
+	if err != nil {
+		return err
+	}
+	if exists {
+		m.recordBlob(target, desc.Digest)
+		result.BlobsSkipped++
+		result.BytesSkipped += desc.Size
+		return nil
+	}
+
+	if m.DryRun {
+		// 같은 blob을 다시 세지 않도록 복사된 것으로 기록
+		m.recordBlob(target, desc.Digest)
+		if known {
+			result.BlobsMounted++
+			return nil
+		}
+		result.BlobsCopied++
+		result.BytesCopied += desc.Size
+		return nil
+	}
+
+	if known {
+		mounted, err := m.Client.MountBlob(ctx, target, desc.Digest, mountFrom)
+		if err == nil && mounted {
+			m.recordBlob(target, desc.Digest)
+			result.BlobsMounted++
+			return nil
+		}
+	}
+
+	body, _, err := m.Client.GetBlob(ctx, source, desc.Digest)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	if err := m.Client.PutBlob(ctx, target, desc.Digest, desc.Size, body); err != nil {
+		return err
+	}
+	m.recordBlob(target, desc.Digest)
+	result.BlobsCopied++
+	result.BytesCopied += desc.Size
+	return nil
+}
+
+// knownBlob 대상 레지스트리에서 blob이 있는 저장소를 찾음 (같은 저장소를 우선)
+func (m *Mirror) knownBlob(target registry.Reference, digest string) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	repositories := m.blobs[target.Registry+"@"+digest]
+	if repositories[target.Repository] {
+		return target.Repository, true
+	}
+	for repository := range repositories {
+		return repository, true
+	}
+	return "", false
+}
+
+// recordBlob 대상 저장소에 blob이 있음을 기록
+func (m *Mirror) recordBlob(target registry.Reference, digest string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := target.Registry + "@" + digest
+	if m.blobs[key] == nil {
+		m.blobs[key] = make(map[string]bool)
+	}
+	m.blobs[key][target.Repository] = true
+}
@@ -0,0 +1,204 @@
+package mirror
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// fakeRegistry 매니페스트와 blob을 메모리에 저장하는 최소한의 distribution 레지스트리
+type fakeRegistry struct {
+	mu        sync.Mutex
+	manifests map[string][]byte
+	blobs     map[string][]byte
+	// tagPuts 태그별 매니페스트 PUT 횟수
+	tagPuts map[string]int
+	uploads int
+}
+
+func newFakeRegistry(t *testing.T) (*fakeRegistry, *httptest.Server) {
+	r := &fakeRegistry{manifests: make(map[string][]byte), blobs: make(map[string][]byte), tagPuts: make(map[string]int)}
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+	return r, server
+}
+
+func (r *fakeRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	path := strings.TrimPrefix(req.URL.Path, "/v2/")
+	switch {
+	case strings.HasPrefix(req.URL.Path, "/upload/"):
+		repository := strings.TrimPrefix(req.URL.Path, "/upload/")
+		body, _ := io.ReadAll(req.Body)
+		r.blobs[repository+"@"+req.URL.Query().Get("digest")] = body
+		w.WriteHeader(http.StatusCreated)
+
+	case strings.HasSuffix(path, "/blobs/uploads/"):
+		repository := strings.TrimSuffix(path, "/blobs/uploads/")
+		query := req.URL.Query()
+		if blob, ok := r.blobs[query.Get("from")+"@"+query.Get("mount")]; ok {
+			r.blobs[repository+"@"+query.Get("mount")] = blob
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		w.Header().Set("Location", "/upload/"+repository)
+		w.WriteHeader(http.StatusAccepted)
+
+	case strings.Contains(path, "/blobs/"):
+		repository, digest, _ := strings.Cut(path, "/blobs/")
+		blob, ok := r.blobs[repository+"@"+digest]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Length", fmt.Sprint(len(blob)))
+		w.Write(blob)
+
+	case strings.Contains(path, "/manifests/"):
+		repository, reference, _ := strings.Cut(path, "/manifests/")
+		key := repository + ":" + reference
+		if req.Method == "PUT" {
+			body, _ := io.ReadAll(req.Body)
+			r.manifests[key] = body
+			r.manifests[repository+":"+registry.Digest(body)] = body
+			if !strings.HasPrefix(reference, "sha256:") {
+				r.tagPuts[key]++
+			}
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		body, ok := r.manifests[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var manifest registry.Manifest
+		json.Unmarshal(body, &manifest)
+		w.Header().Set("Content-Type", manifest.MediaType)
+		w.Header().Set("Docker-Content-Digest", registry.Digest(body))
+		if req.Method != "HEAD" {
+			w.Write(body)
+		}
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// addBlob blob을 저장하고 descriptor를 반환
+func (r *fakeRegistry) addBlob(repository, mediaType string, content []byte) registry.Descriptor {
+	digest := registry.Digest(content)
+	r.blobs[repository+"@"+digest] = content
+	return registry.Descriptor{MediaType: mediaType, Digest: digest, Size: int64(len(content))}
+}
+
+// addManifest 매니페스트를 digest(와 태그)로 저장하고 descriptor를 반환
+func (r *fakeRegistry) addManifest(t *testing.T, repository string, manifest registry.Manifest, tag string) registry.Descriptor {
+	body, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := registry.Digest(body)
+	r.manifests[repository+":"+digest] = body
+	if tag != "" {
+		r.manifests[repository+":"+tag] = body
+	}
+	return registry.Descriptor{MediaType: manifest.MediaType, Digest: digest, Size: int64(len(body))}
+}
+
+// addImage 플랫폼 이미지 매니페스트와 config, 레이어를 저장
+func (r *fakeRegistry) addImage(t *testing.T, repository, platform, tag string) registry.Descriptor {
+	config := r.addBlob(repository, "application/vnd.oci.image.config.v1+json", []byte(`{"architecture":"`+platform+`"}`))
+	layer := r.addBlob(repository, "application/vnd.oci.image.layer.v1.tar+gzip", []byte("layer-"+platform))
+	return r.addManifest(t, repository, registry.Manifest{
+		SchemaVersion: 2,
+		MediaType:     "application/vnd.oci.image.manifest.v1+json",
+		Config:        config,
+		Layers:        []registry.Descriptor{layer},
+	}, tag)
+}
+
+func newTestMirror(source, target *httptest.Server) *Mirror {
+	client := &registry.Client{
+		HTTPClient: http.DefaultClient,
+		Endpoints:  map[string]string{"src.example.com": source.URL, "dst.example.com": target.URL},
+	}
+	return NewMirror(client, 4, false)
+}
+
+func TestCopyPlatformDigestCopiesTagIndex(t *testing.T) {
+	source, sourceServer := newFakeRegistry(t)
+	target, targetServer := newFakeRegistry(t)
+
+	amd64 := source.addImage(t, "team/app", "amd64", "")
+	arm64 := source.addImage(t, "team/app", "arm64", "")
+	index := source.addManifest(t, "team/app", registry.Manifest{
+		SchemaVersion: 2,
+		MediaType:     "application/vnd.oci.image.index.v1+json",
+		Manifests:     []registry.Descriptor{amd64, arm64},
+	}, "1.0")
+
+	// 노드 아키텍처에 따라 같은 태그의 파드가 서로 다른 플랫폼 digest로 실행 중
+	var images []Image
+	for _, digest := range []string{amd64.Digest, arm64.Digest} {
+		ref := registry.Reference{Registry: "src.example.com", Repository: "team/app", Tag: "1.0", Digest: digest}
+		images = append(images, Image{Source: ref, Target: TargetReference("dst.example.com/mirror", ref), Pods: 1})
+	}
+
+	results := newTestMirror(sourceServer, targetServer).Run(context.Background(), images)
+	for _, result := range results {
+		if result.Error != "" {
+			t.Fatalf("Copy(%s) error = %s", result.Source, result.Error)
+		}
+		if result.Digest != index.Digest {
+			t.Errorf("Copy(%s) digest = %s, want index %s", result.Source, result.Digest, index.Digest)
+		}
+	}
+
+	tagKey := "mirror/src.example.com/team/app:1.0"
+	if got := registry.Digest(target.manifests[tagKey]); got != index.Digest {
+		t.Errorf("target tag points to %s, want index %s", got, index.Digest)
+	}
+	if puts := target.tagPuts[tagKey]; puts != 1 {
+		t.Errorf("target tag PUT %d times, want 1", puts)
+	}
+	for _, desc := range []registry.Descriptor{amd64, arm64} {
+		if _, ok := target.manifests["mirror/src.example.com/team/app:"+desc.Digest]; !ok {
+			t.Errorf("child manifest %s was not copied", desc.Digest)
+		}
+	}
+}
+
+func TestCopyKeepsTargetTagWhenSourceTagMoved(t *testing.T) {
+	source, sourceServer := newFakeRegistry(t)
+	target, targetServer := newFakeRegistry(t)
+
+	running := source.addImage(t, "team/app", "amd64", "")
+	source.addImage(t, "team/app", "amd64-rebuilt", "1.0")
+
+	ref := registry.Reference{Registry: "src.example.com", Repository: "team/app", Tag: "1.0", Digest: running.Digest}
+	result := newTestMirror(sourceServer, targetServer).Copy(context.Background(),
+		Image{Source: ref, Target: TargetReference("dst.example.com/mirror", ref), Pods: 1})
+	if result.Error != "" {
+		t.Fatalf("Copy() error = %s", result.Error)
+	}
+	if result.Digest != running.Digest {
+		t.Errorf("Copy() digest = %s, want running digest %s", result.Digest, running.Digest)
+	}
+	if _, ok := target.manifests["mirror/src.example.com/team/app:1.0"]; ok {
+		t.Errorf("target tag was set although the source tag no longer contains the running digest")
+	}
+	if _, ok := target.manifests["mirror/src.example.com/team/app:"+running.Digest]; !ok {
+		t.Errorf("running manifest was not copied")
+	}
+}
@@ -0,0 +1,79 @@
+package mirror
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/units"
+)
+
+// PrintResults 이미지별 미러링 결과와 복사한 전송량 요약 출력
+func PrintResults(results []Result, dryRun bool) {
+	title := "Image Mirroring"
+	if dryRun {
+		title += " (dry run)"
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "\n%s:\n", title)
+	fmt.Fprintln(w, "=======================================================================")
+	fmt.Fprintln(w, "No.\tSource\tTarget\tDigest\tManifests\tCopied\tMounted\tSkipped\tBytes Copied\tStatus")
+	fmt.Fprintln(w, "-----------------------------------------------------------------------")
+	for i, result := range results {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%d\t%d\t%d\t%d\t%s\t%s\n", i+1, displayName(result.Source.String()),
+			result.Target.WithTag(result.Source.Tag).String(), shortDigest(result.Digest), result.Manifests,
+			result.BlobsCopied, result.BlobsMounted, result.BlobsSkipped, units.FormatBytes(result.BytesCopied), result.Status)
+	}
+	fmt.Fprintln(w, "=======================================================================")
+	w.Flush()
+
+	// 요약 정보 출력
+	counts := make(map[string]int)
+	var copied, mounted, skipped int
+	var bytesCopied, bytesSkipped int64
+	for _, result := range results {
+		counts[result.Status]++
+		copied += result.BlobsCopied
+		mounted += result.BlobsMounted
+		skipped += result.BlobsSkipped
+		bytesCopied += result.BytesCopied
+		bytesSkipped += result.BytesSkipped
+	}
+	verb := "Copied"
+	if dryRun {
+		verb = "Would copy"
+	}
+	fmt.Printf("\nSummary:\n")
+	fmt.Printf("- Images: %d (%s: %d, up-to-date: %d, failed: %d)\n", len(results), strings.ToLower(verb),
+		counts[StatusCopied]+counts[StatusDryRun], counts[StatusUpToDate], counts[StatusFailed])
+	fmt.Printf("- %s: %d blobs, %s\n", verb, copied, units.FormatBytes(bytesCopied))
+	fmt.Printf("- Mounted from other repositories: %d blobs\n", mounted)
+	fmt.Printf("- Already in target: %d blobs, %s\n", skipped, units.FormatBytes(bytesSkipped))
+	for _, result := range results {
+		if result.Error != "" {
+			fmt.Printf("  - %s: %s\n", result.Source, result.Error)
+		}
+	}
+}
+
+// displayName digest가 포함된 참조를 "이름:태그@sha256:<12자리>"로 줄여서 표시
+func displayName(reference string) string {
+	name, digest, ok := strings.Cut(reference, "@")
+	if !ok {
+		return reference
+	}
+	return name + "@" + shortDigest(digest)
+}
+
+// shortDigest digest를 "sha256:" 이후 12자리로 줄여서 표시
+func shortDigest(digest string) string {
+	algorithm, hex, ok := strings.Cut(digest, ":")
+	if !ok || len(hex) <= 12 {
+		if digest == "" {
+			return "-"
+		}
+		return digest
+	}
+	return algorithm + ":" + hex[:12]
+}
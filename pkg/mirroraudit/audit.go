@@ -0,0 +1,162 @@
+package mirroraudit
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/mirrorconfig"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// 풀 경로 상태
+const (
+	StatusMirrored    = "Mirrored"
+	StatusOtherMirror = "Other mirror"
+	StatusUpstream    = "Upstream"
+)
+
+// PullAudit 노드에서 풀한 참조 하나가 노드 설정상 어디로 향하는지 확인한 결과
+type PullAudit struct {
+	Reference string
+	Registry  string
+	Pulls     int
+	// Expected 사용해야 하는 미러 위치 (매핑이 없으면 노드 설정의 첫 미러, 미러가 없으면 빈 문자열)
+	Expected string
+	// Mirrors 이 참조에 실제로 사용되는 노드 설정의 미러 위치
+	Mirrors []string
+	Status  string
+	Reason  string
+}
+
+// Bypassed 미러를 거쳐야 하는데 upstream으로 직접 풀했는지 확인
+func (a PullAudit) Bypassed() bool {
+	return a.Status == StatusUpstream && a.Expected != ""
+}
+
+// Audit 풀 이벤트의 참조마다 노드 설정의 미러 규칙을 적용하여 upstream으로 직접 풀한 참조를 찾음
+// expected는 upstream 호스트별로 기대하는 미러 위치 (mirrorconfig 매핑과 같은 형식)
+func Audit(config *NodeConfig, pullEvents []string, expected map[string]string) []PullAudit {
+	var audits []PullAudit
+	for reference, count := range kubernetes.CountPullsByReference(pullEvents) {
+		ref, err := registry.ParseReference(reference)
+		if err != nil {
+			continue
+		}
+		audit := PullAudit{Reference: reference, Registry: ref.Registry, Pulls: count}
+
+		rule := config.match(ref)
+		location, mapped := expected[ref.Registry]
+		if mapped {
+			audit.Expected = location
+			// 저장소 단위 규칙이면 기대 위치에도 같은 경로를 붙임
+			if rule != nil && strings.HasPrefix(rule.Prefix, ref.Registry+"/") {
+				audit.Expected += strings.TrimPrefix(rule.Prefix, ref.Registry)
+			}
+		} else if rule != nil && len(rule.Mirrors) > 0 {
+			audit.Expected = rule.Mirrors[0].Location
+		}
+
+		var skipped []string
+		if rule != nil {
+			if rule.Location != rule.Prefix {
+				audit.Mirrors = append(audit.Mirrors, rule.Location)
+			}
+			for _, mirror := range rule.Mirrors {
+				if usable(mirror, ref) {
+					audit.Mirrors = append(audit.Mirrors, mirror.Location)
+				} else {
+					skipped = append(skipped, mirror.Location+" is "+mirror.PullFrom)
+				}
+			}
+		}
+
+		switch {
+		case len(audit.Mirrors) == 0 && len(skipped) > 0:
+			audit.Status = StatusUpstream
+			audit.Reason = fmt.Sprintf("pulled by %s but %s", referenceKind(ref), strings.Join(skipped, ", "))
+			if config.Runtime == mirrorconfig.RuntimeContainerd {
+				audit.Reason = fmt.Sprintf("pulled by %s but mirror has no resolve capability", referenceKind(ref))
+			}
+		case len(audit.Mirrors) == 0:
+			audit.Status = StatusUpstream
+			audit.Reason = "no mirror configured for " + ref.Registry
+		case mapped && !slices.Contains(audit.Mirrors, normalizeLocation(audit.Expected)):
+			audit.Status = StatusOtherMirror
+			audit.Reason = "expected mirror is not configured"
+		default:
+			audit.Status = StatusMirrored
+		}
+		audits = append(audits, audit)
+	}
+
+	sort.Slice(audits, func(i, j int) bool {
+		if audits[i].Bypassed() != audits[j].Bypassed() {
+			return audits[i].Bypassed()
+		}
+		if audits[i].Pulls != audits[j].Pulls {
+			return audits[i].Pulls > audits[j].Pulls
+		}
+		return audits[i].Reference < audits[j].Reference
+	})
+	return audits
+}
+
+// Expected mirror-config 와 같은 매핑/--to 규칙으로 관찰된 레지스트리별 기대 미러 위치를 계산
+func Expected(pullEvents []string, opts mirrorconfig.Options) map[string]string {
+	expected := make(map[string]string)
+	if len(opts.Mapping) == 0 && opts.Target == "" {
+		return expected
+	}
+	entries, _ := mirrorconfig.Entries(kubernetes.CountPullsByRegistry(pullEvents), opts)
+	for _, entry := range entries {
+		expected[entry.Upstream] = entry.Mirror
+	}
+	return expected
+}
+
+// match 참조에 적용되는 규칙을 찾음 (가장 긴 접두사 우선, "*.host" 와일드카드, "*"는 기본 규칙)
+func (c *NodeConfig) match(ref registry.Reference) *Rule {
+	name := ref.Name()
+	var best *Rule
+	bestLength := -1
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		length := -1
+		switch {
+		case rule.Prefix == "*":
+			length = 0
+		case strings.HasPrefix(rule.Prefix, "*."):
+			if strings.HasSuffix(ref.Registry, rule.Prefix[1:]) {
+				length = len(rule.Prefix)
+			}
+		case name == rule.Prefix || strings.HasPrefix(name, rule.Prefix+"/"):
+			length = len(rule.Prefix)
+		}
+		if length > bestLength {
+			best, bestLength = rule, length
+		}
+	}
+	return best
+}
+
+// usable 미러가 참조 종류(태그/digest)에 사용되는지 확인
+func usable(mirror Mirror, ref registry.Reference) bool {
+	switch mirror.PullFrom {
+	case PullFromDigestOnly:
+		return ref.Digest != ""
+	case PullFromTagOnly:
+		return ref.Digest == ""
+	}
+	return true
+}
+
+// referenceKind 참조가 digest인지 태그인지 표시
+func referenceKind(ref registry.Reference) string {
+	if ref.Digest != "" {
+		return "digest"
+	}
+	return "tag"
+}
@@ -0,0 +1,275 @@
+package mirroraudit
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/suslmk-lee/zim-image-management/pkg/mirrorconfig"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// 노드 설정 기본 경로
+const (
+	DefaultCRIOConfig = "/etc/containers/registries.conf"
+)
+
+// CRI-O pull-from-mirror 값
+const (
+	PullFromAll        = "all"
+	PullFromDigestOnly = "digest-only"
+	PullFromTagOnly    = "tag-only"
+)
+
+// Mirror 노드 설정에 있는 미러 하나
+type Mirror struct {
+	// Location 미러 위치 host[/path]
+	Location string
+	// PullFrom 미러를 사용하는 참조 종류 (all, digest-only, tag-only)
+	PullFrom string
+}
+
+// Rule 레지스트리(또는 저장소 접두사)에 적용되는 미러 규칙
+type Rule struct {
+	// Prefix 규칙이 적용되는 참조 접두사 (CRI-O는 "*.example.com" 와일드카드 허용)
+	Prefix string
+	// Location 접두사를 바꿔 쓰는 위치 (CRI-O location, 접두사와 같으면 재작성 없음)
+	Location string
+	Blocked  bool
+	Mirrors  []Mirror
+	// File 규칙을 읽은 파일
+	File string
+}
+
+// NodeConfig 노드의 컨테이너 런타임 미러 설정
+type NodeConfig struct {
+	Runtime string
+	Rules   []Rule
+	Files   []string
+}
+
+// criOConfig registries.conf(v2) 중 필요한 필드
+type criOConfig struct {
+	Registries []criORegistry `toml:"registry"`
+}
+
+// criORegistry [[registry]] 테이블
+type criORegistry struct {
+	Prefix             string `toml:"prefix"`
+	Location           string `toml:"location"`
+	Blocked            bool   `toml:"blocked"`
+	MirrorByDigestOnly bool   `toml:"mirror-by-digest-only"`
+	Mirrors            []struct {
+		Location       string `toml:"location"`
+		PullFromMirror string `toml:"pull-from-mirror"`
+	} `toml:"mirror"`
+}
+
+// containerdHostsFile hosts.toml 중 필요한 필드
+type containerdHostsFile struct {
+	Hosts map[string]containerdHost `toml:"host"`
+}
+
+// containerdHost [host."<url>"] 테이블 (capabilities가 없으면 nil)
+type containerdHost struct {
+	Capabilities []string `toml:"capabilities"`
+	OverridePath bool     `toml:"override_path"`
+}
+
+// LoadCRIOConfig registries.conf와 drop-in 디렉터리의 *.conf 파일을 순서대로 읽음
+// 디렉터리는 파일 이름 순으로 읽고, 같은 prefix의 규칙은 나중 파일이 덮어씀 (containers-registries.conf.d 규칙)
+func LoadCRIOConfig(paths []string) (*NodeConfig, error) {
+	config := &NodeConfig{Runtime: mirrorconfig.RuntimeCRIO}
+	rules := make(map[string]int)
+
+	for _, path := range paths {
+		files, err := configFiles(path, ".conf")
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %v", file, err)
+			}
+			var parsed criOConfig
+			if _, err := toml.Decode(string(data), &parsed); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %v", file, err)
+			}
+			config.Files = append(config.Files, file)
+
+			for _, table := range parsed.Registries {
+				rule := criORule(table, file)
+				if rule.Prefix == "" {
+					continue
+				}
+				if i, ok := rules[rule.Prefix]; ok {
+					config.Rules[i] = rule
+					continue
+				}
+				rules[rule.Prefix] = len(config.Rules)
+				config.Rules = append(config.Rules, rule)
+			}
+		}
+	}
+	return config, nil
+}
+
+// criORule [[registry]] 테이블을 규칙으로 변환 (prefix가 없으면 location 사용, 구 mirror-by-digest-only 지원)
+func criORule(table criORegistry, file string) Rule {
+	rule := Rule{Prefix: table.Prefix, Location: table.Location, Blocked: table.Blocked, File: file}
+	if rule.Prefix == "" {
+		rule.Prefix = rule.Location
+	}
+	if rule.Location == "" {
+		rule.Location = rule.Prefix
+	}
+	rule.Prefix = normalizeLocation(rule.Prefix)
+	rule.Location = normalizeLocation(rule.Location)
+
+	defaultPullFrom := PullFromAll
+	if table.MirrorByDigestOnly {
+		defaultPullFrom = PullFromDigestOnly
+	}
+	for _, mirror := range table.Mirrors {
+		pullFrom := mirror.PullFromMirror
+		if pullFrom == "" {
+			pullFrom = defaultPullFrom
+		}
+		rule.Mirrors = append(rule.Mirrors, Mirror{Location: normalizeLocation(mirror.Location), PullFrom: pullFrom})
+	}
+	return rule
+}
+
+// LoadContainerdConfig containerd config_path 디렉터리의 <host>/hosts.toml 파일을 읽음
+// capabilities에 pull이 있는 host만 미러로 사용하며, resolve가 없으면 digest 참조에만 사용됨
+func LoadContainerdConfig(certsDir string) (*NodeConfig, error) {
+	config := &NodeConfig{Runtime: mirrorconfig.RuntimeContainerd}
+
+	entries, err := os.ReadDir(certsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", certsDir, err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		file := filepath.Join(certsDir, entry.Name(), "hosts.toml")
+		data, err := os.ReadFile(file)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", file, err)
+		}
+		var parsed containerdHostsFile
+		metadata, err := toml.Decode(string(data), &parsed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %v", file, err)
+		}
+		config.Files = append(config.Files, file)
+
+		// 디렉터리 이름이 레지스트리 호스트 (_default는 모든 레지스트리)
+		prefix := registry.NormalizeRegistry(entry.Name())
+		if entry.Name() == "_default" {
+			prefix = "*"
+		}
+		rule := Rule{Prefix: prefix, Location: prefix, File: file}
+		// containerd는 host를 파일에 나온 순서대로 시도
+		for _, hostURL := range hostOrder(metadata) {
+			host := parsed.Hosts[hostURL]
+			capabilities := host.Capabilities
+			if capabilities == nil {
+				capabilities = []string{"pull", "resolve"}
+			}
+			if !slices.Contains(capabilities, "pull") {
+				continue
+			}
+			pullFrom := PullFromAll
+			if !slices.Contains(capabilities, "resolve") {
+				pullFrom = PullFromDigestOnly
+			}
+			rule.Mirrors = append(rule.Mirrors, Mirror{Location: containerdLocation(hostURL, host.OverridePath), PullFrom: pullFrom})
+		}
+		config.Rules = append(config.Rules, rule)
+	}
+	return config, nil
+}
+
+// hostOrder hosts.toml의 [host."<url>"] 테이블 이름을 파일에 나온 순서대로 반환
+func hostOrder(metadata toml.MetaData) []string {
+	var hosts []string
+	seen := make(map[string]bool)
+	for _, key := range metadata.Keys() {
+		if len(key) < 2 || key[0] != "host" || seen[key[1]] {
+			continue
+		}
+		seen[key[1]] = true
+		hosts = append(hosts, key[1])
+	}
+	return hosts
+}
+
+// containerdLocation hosts.toml host URL을 host[/path] 미러 위치로 변환
+// override_path가 있으면 URL 경로의 "/v2" 접두사를 제거 (zim mirror-config가 생성하는 형식)
+func containerdLocation(hostURL string, overridePath bool) string {
+	parsed, err := url.Parse(hostURL)
+	if err != nil || parsed.Host == "" {
+		return normalizeLocation(hostURL)
+	}
+	path := strings.Trim(parsed.Path, "/")
+	if overridePath {
+		path = strings.Trim(strings.TrimPrefix(path, "v2"), "/")
+	}
+	if path == "" {
+		return normalizeLocation(parsed.Host)
+	}
+	return normalizeLocation(parsed.Host + "/" + path)
+}
+
+// configFiles 경로가 디렉터리면 확장자가 맞는 파일을 이름 순으로, 파일이면 그대로 반환 (없는 경로는 무시)
+func configFiles(path, extension string) ([]string, error) {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), extension) {
+			files = append(files, filepath.Join(path, entry.Name()))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// normalizeLocation 위치를 비교 가능한 형태로 정규화 (레지스트리 호스트 별칭 통일, 끝의 '/' 제거)
+func normalizeLocation(location string) string {
+	location = strings.Trim(strings.TrimSpace(location), "/")
+	host, path, hasPath := strings.Cut(location, "/")
+	if strings.HasPrefix(host, "*.") {
+		host = strings.ToLower(host)
+	} else {
+		host = registry.NormalizeRegistry(host)
+	}
+	if hasPath {
+		return host + "/" + path
+	}
+	return host
+}
@@ -0,0 +1,167 @@
+package mirroraudit
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/mirrorconfig"
+)
+
+func TestLoadCRIOConfig(t *testing.T) {
+	base := filepath.Join("testdata", "crio", "registries.conf")
+	dropIns := filepath.Join("testdata", "crio", "registries.conf.d")
+
+	tests := []struct {
+		name      string
+		paths     []string
+		wantFiles []string
+		wantRules []Rule
+	}{
+		{
+			name:      "registries.conf",
+			paths:     []string{base},
+			wantFiles: []string{base},
+			wantRules: []Rule{
+				{Prefix: "docker.io", Location: "docker.io", File: base, Mirrors: []Mirror{
+					{Location: "mirror.gcr.io", PullFrom: PullFromAll},
+					{Location: "registry.internal:5000/docker.io", PullFrom: PullFromAll},
+				}},
+				// prefix가 없으면 location, mirror-by-digest-only는 pull-from-mirror 기본값
+				{Prefix: "quay.io", Location: "quay.io", File: base, Mirrors: []Mirror{
+					{Location: "quay-cache.internal", PullFrom: PullFromDigestOnly},
+				}},
+				{Prefix: "*.blocked.example.com", Location: "*.blocked.example.com", Blocked: true, File: base},
+			},
+		},
+		{
+			// drop-in은 이름 순으로 읽고 같은 prefix의 규칙을 덮어씀, .conf가 아닌 파일은 무시
+			name:  "with drop-in directory",
+			paths: []string{base, dropIns, filepath.Join("testdata", "crio", "missing.conf")},
+			wantFiles: []string{
+				base,
+				filepath.Join(dropIns, "50-zim-mirrors.conf"),
+				filepath.Join(dropIns, "60-ghcr.conf"),
+			},
+			wantRules: []Rule{
+				{Prefix: "docker.io", Location: "docker.io", File: filepath.Join(dropIns, "50-zim-mirrors.conf"), Mirrors: []Mirror{
+					{Location: "registry.internal/docker.io", PullFrom: PullFromAll},
+				}},
+				{Prefix: "quay.io", Location: "quay.io", File: base, Mirrors: []Mirror{
+					{Location: "quay-cache.internal", PullFrom: PullFromDigestOnly},
+				}},
+				{Prefix: "*.blocked.example.com", Location: "*.blocked.example.com", Blocked: true, File: base},
+				{Prefix: "ghcr.io/team", Location: "ghcr.io/team", File: filepath.Join(dropIns, "60-ghcr.conf"), Mirrors: []Mirror{
+					{Location: "registry.internal/ghcr.io/team", PullFrom: PullFromTagOnly},
+				}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := LoadCRIOConfig(tt.paths)
+			if err != nil {
+				t.Fatalf("LoadCRIOConfig() error = %v", err)
+			}
+			if config.Runtime != mirrorconfig.RuntimeCRIO {
+				t.Errorf("Runtime = %q, want %q", config.Runtime, mirrorconfig.RuntimeCRIO)
+			}
+			if !reflect.DeepEqual(config.Files, tt.wantFiles) {
+				t.Errorf("Files = %v, want %v", config.Files, tt.wantFiles)
+			}
+			if !reflect.DeepEqual(config.Rules, tt.wantRules) {
+				t.Errorf("Rules =\n%+v\nwant\n%+v", config.Rules, tt.wantRules)
+			}
+		})
+	}
+}
+
+func TestLoadContainerdConfig(t *testing.T) {
+	certsDir := filepath.Join("testdata", "certs.d")
+	hostsFile := func(host string) string {
+		return filepath.Join(certsDir, host, "hosts.toml")
+	}
+
+	config, err := LoadContainerdConfig(certsDir)
+	if err != nil {
+		t.Fatalf("LoadContainerdConfig() error = %v", err)
+	}
+	if config.Runtime != mirrorconfig.RuntimeContainerd {
+		t.Errorf("Runtime = %q, want %q", config.Runtime, mirrorconfig.RuntimeContainerd)
+	}
+
+	// hosts.toml이 없는 디렉터리는 건너뜀
+	wantFiles := []string{hostsFile("_default"), hostsFile("docker.io"), hostsFile("quay.io"), hostsFile("registry.k8s.io")}
+	if !reflect.DeepEqual(config.Files, wantFiles) {
+		t.Errorf("Files = %v, want %v", config.Files, wantFiles)
+	}
+
+	wantRules := []Rule{
+		{Prefix: "*", Location: "*", File: hostsFile("_default"), Mirrors: []Mirror{
+			{Location: "cache.internal", PullFrom: PullFromAll},
+		}},
+		// 파일에 나온 순서 유지, resolve가 없으면 digest 전용, pull이 없는 host는 제외
+		{Prefix: "docker.io", Location: "docker.io", File: hostsFile("docker.io"), Mirrors: []Mirror{
+			{Location: "mirror-b.internal", PullFrom: PullFromDigestOnly},
+			{Location: "mirror-a.internal/docker.io", PullFrom: PullFromAll},
+		}},
+		// capabilities가 없으면 pull, resolve
+		{Prefix: "quay.io", Location: "quay.io", File: hostsFile("quay.io"), Mirrors: []Mirror{
+			{Location: "registry.internal:5000", PullFrom: PullFromAll},
+		}},
+		{Prefix: "registry.k8s.io", Location: "registry.k8s.io", File: hostsFile("registry.k8s.io")},
+	}
+	if !reflect.DeepEqual(config.Rules, wantRules) {
+		t.Errorf("Rules =\n%+v\nwant\n%+v", config.Rules, wantRules)
+	}
+}
+
+func TestLoadContainerdConfigGenerated(t *testing.T) {
+	// zim mirror-config가 생성한 hosts.toml을 다시 읽으면 같은 미러 위치가 나와야 함
+	entries := []mirrorconfig.Entry{
+		{Upstream: "docker.io", Mirror: "registry.internal/docker.io"},
+		{Upstream: "quay.io", Mirror: "registry.internal:5000", Insecure: true},
+	}
+	dir := t.TempDir()
+	if _, err := mirrorconfig.WriteTree(dir, mirrorconfig.ContainerdFiles(entries)); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadContainerdConfig(filepath.Join(dir, filepath.FromSlash(mirrorconfig.ContainerdCertsDir)))
+	if err != nil {
+		t.Fatalf("LoadContainerdConfig() error = %v", err)
+	}
+	if len(config.Rules) != len(entries) {
+		t.Fatalf("got %d rules, want %d", len(config.Rules), len(entries))
+	}
+	for i, entry := range entries {
+		rule := config.Rules[i]
+		want := []Mirror{{Location: entry.Mirror, PullFrom: PullFromAll}}
+		if rule.Prefix != entry.Upstream || !reflect.DeepEqual(rule.Mirrors, want) {
+			t.Errorf("rule %d = %+v, want prefix %s and mirrors %+v", i, rule, entry.Upstream, want)
+		}
+	}
+}
+
+func TestLoadConfigInvalidTOML(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "registries.conf")
+	if err := os.WriteFile(file, []byte("[[registry]\nprefix = \"docker.io\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadCRIOConfig([]string{file}); err == nil {
+		t.Errorf("LoadCRIOConfig() error = nil, want parse error")
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "certs.d", "docker.io"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "certs.d", "docker.io", "hosts.toml"), []byte("capabilities = [\"pull\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadContainerdConfig(filepath.Join(dir, "certs.d")); err == nil {
+		t.Errorf("LoadContainerdConfig() error = nil, want parse error")
+	}
+}
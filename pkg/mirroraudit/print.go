@@ -0,0 +1,79 @@
+package mirroraudit
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// CountBypassed 미러를 거치지 않고 upstream으로 직접 풀한 횟수
+func CountBypassed(audits []PullAudit) int {
+	var pulls int
+	for _, audit := range audits {
+		if audit.Bypassed() {
+			pulls += audit.Pulls
+		}
+	}
+	return pulls
+}
+
+// PrintAudit 노드의 참조별 풀 경로와 upstream 직접 풀 요약 출력 (all이면 미러를 거친 참조도 포함)
+func PrintAudit(node string, config *NodeConfig, audits []PullAudit, all bool) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "\nMirror Audit (node %s, %s):\n", node, config.Runtime)
+	fmt.Fprintln(w, "=======================================================================")
+	fmt.Fprintln(w, "No.\tImage\tPulls\tExpected Mirror\tNode Mirrors\tStatus\tReason")
+	fmt.Fprintln(w, "-----------------------------------------------------------------------")
+	no := 0
+	for _, audit := range audits {
+		if !all && !audit.Bypassed() && audit.Status != StatusOtherMirror {
+			continue
+		}
+		no++
+		fmt.Fprintf(w, "%d\t%s\t%d\t%s\t%s\t%s\t%s\n", no, audit.Reference, audit.Pulls, orDash(audit.Expected),
+			orDash(strings.Join(audit.Mirrors, ",")), audit.Status, orDash(audit.Reason))
+	}
+	fmt.Fprintln(w, "=======================================================================")
+	w.Flush()
+
+	// 요약 정보 출력
+	var total, mirrored, other int
+	bypassed := make(map[string]int)
+	for _, audit := range audits {
+		total += audit.Pulls
+		switch {
+		case audit.Status == StatusMirrored:
+			mirrored += audit.Pulls
+		case audit.Status == StatusOtherMirror:
+			other += audit.Pulls
+		case audit.Bypassed():
+			bypassed[audit.Registry] += audit.Pulls
+		}
+	}
+	fmt.Printf("\nSummary:\n")
+	fmt.Printf("- Configuration files: %d\n", len(config.Files))
+	for _, file := range config.Files {
+		fmt.Printf("  - %s\n", file)
+	}
+	fmt.Printf("- Pulls checked: %d\n", total)
+	fmt.Printf("- Through a mirror: %d (unexpected mirror: %d)\n", mirrored+other, other)
+	fmt.Printf("- Straight to upstream despite an expected mirror: %d\n", CountBypassed(audits))
+	var registries []string
+	for host := range bypassed {
+		registries = append(registries, host)
+	}
+	sort.Strings(registries)
+	for _, host := range registries {
+		fmt.Printf("  - %s: %d pulls\n", host, bypassed[host])
+	}
+}
+
+// orDash 빈 문자열을 "-"로 표시
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,159 @@
+package mirrorconfig
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// 번들 기본값
+const (
+	// DefaultInstallerImage DaemonSet이 파일을 복사할 때 사용하는 이미지
+	DefaultInstallerImage = "busybox:1.36"
+	// ignitionVersion MachineConfig에 사용하는 Ignition 스펙 버전
+	ignitionVersion = "3.2.0"
+)
+
+// MachineConfig OpenShift MachineConfig 매니페스트를 생성 (파일을 data URL로 포함, MCO가 노드에 배포)
+func MachineConfig(files []File, role string) ([]byte, error) {
+	var ignitionFiles []map[string]interface{}
+	for _, file := range files {
+		ignitionFiles = append(ignitionFiles, map[string]interface{}{
+			"path":      file.Path,
+			"mode":      0644,
+			"overwrite": true,
+			"contents": map[string]interface{}{
+				"source": "data:text/plain;charset=utf-8;base64," + base64.StdEncoding.EncodeToString(file.Content),
+			},
+		})
+	}
+
+	machineConfig := map[string]interface{}{
+		"apiVersion": "machineconfiguration.openshift.io/v1",
+		"kind":       "MachineConfig",
+		"metadata": map[string]interface{}{
+			"name":   fmt.Sprintf("99-%s-zim-registry-mirrors", role),
+			"labels": map[string]string{"machineconfiguration.openshift.io/role": role},
+		},
+		"spec": map[string]interface{}{
+			"config": map[string]interface{}{
+				"ignition": map[string]string{"version": ignitionVersion},
+				"storage":  map[string]interface{}{"files": ignitionFiles},
+			},
+		},
+	}
+	data, err := yaml.Marshal(machineConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode MachineConfig: %v", err)
+	}
+	return data, nil
+}
+
+// DaemonSet ConfigMap과 파일을 노드의 hostPath로 복사하는 DaemonSet 매니페스트를 생성
+// 설정 내용의 hash를 Pod 어노테이션에 넣어 ConfigMap이 바뀌면 다시 배포되도록 함
+func DaemonSet(files []File, namespace, image string) ([]byte, error) {
+	const name = "zim-registry-mirrors"
+
+	data := make(map[string]string)
+	hash := sha256.New()
+	var script []string
+	hostDirs := make(map[string]bool)
+	for _, file := range files {
+		key := configMapKey(file.Path)
+		data[key] = string(file.Content)
+		hash.Write([]byte(file.Path))
+		hash.Write(file.Content)
+		hostDirs[hostDir(file.Path)] = true
+		script = append(script, fmt.Sprintf("mkdir -p /host%s && cp /config/%s /host%s", path.Dir(file.Path), key, file.Path))
+	}
+	if hostDirs[CRIODropInDir] {
+		script = append(script, "echo 'CRI-O reads registries.conf.d on reload: run systemctl reload crio on the node'")
+	}
+	script = append(script, "while true; do sleep 3600; done")
+
+	var dirs []string
+	for dir := range hostDirs {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	var volumes, mounts []map[string]interface{}
+	volumes = append(volumes, map[string]interface{}{"name": "config", "configMap": map[string]string{"name": name}})
+	mounts = append(mounts, map[string]interface{}{"name": "config", "mountPath": "/config", "readOnly": true})
+	for i, dir := range dirs {
+		volume := fmt.Sprintf("host-%d", i)
+		volumes = append(volumes, map[string]interface{}{
+			"name":     volume,
+			"hostPath": map[string]string{"path": dir, "type": "DirectoryOrCreate"},
+		})
+		mounts = append(mounts, map[string]interface{}{"name": volume, "mountPath": "/host" + dir})
+	}
+
+	configMap := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]string{"name": name, "namespace": namespace},
+		"data":       data,
+	}
+	labels := map[string]string{"app.kubernetes.io/name": name}
+	daemonSet := map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "DaemonSet",
+		"metadata":   map[string]interface{}{"name": name, "namespace": namespace, "labels": labels},
+		"spec": map[string]interface{}{
+			"selector": map[string]interface{}{"matchLabels": labels},
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels":      labels,
+					"annotations": map[string]string{"zim/config-hash": hex.EncodeToString(hash.Sum(nil))[:16]},
+				},
+				"spec": map[string]interface{}{
+					"tolerations": []map[string]string{{"operator": "Exists"}},
+					"containers": []map[string]interface{}{{
+						"name":         "install",
+						"image":        image,
+						"command":      []string{"sh", "-c", strings.Join(script, "\n")},
+						"volumeMounts": mounts,
+						"resources": map[string]interface{}{
+							"requests": map[string]string{"cpu": "1m", "memory": "8Mi"},
+						},
+					}},
+					"volumes": volumes,
+				},
+			},
+		},
+	}
+
+	var out []byte
+	for _, object := range []interface{}{configMap, daemonSet} {
+		encoded, err := yaml.Marshal(object)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode DaemonSet bundle: %v", err)
+		}
+		if len(out) > 0 {
+			out = append(out, "---\n"...)
+		}
+		out = append(out, encoded...)
+	}
+	return out, nil
+}
+
+// configMapKey 파일 경로를 ConfigMap 키로 쓸 수 있는 형태로 변환 (예: etc_containerd_certs.d_docker.io_hosts.toml)
+func configMapKey(filePath string) string {
+	return strings.NewReplacer("/", "_", ":", "-").Replace(strings.TrimPrefix(filePath, "/"))
+}
+
+// hostDir 파일이 속한 런타임 설정 디렉터리 (hostPath로 마운트할 위치)
+func hostDir(filePath string) string {
+	for _, dir := range []string{CRIODropInDir, ContainerdCertsDir} {
+		if strings.HasPrefix(filePath, dir+"/") {
+			return dir
+		}
+	}
+	return path.Dir(filePath)
+}
@@ -0,0 +1,119 @@
+package mirrorconfig
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/mirror"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// 설정 파일 경로
+const (
+	// CRIODropInDir CRI-O(containers-registries.conf) drop-in 디렉터리
+	CRIODropInDir = "/etc/containers/registries.conf.d"
+	// CRIOFileName 생성하는 CRI-O drop-in 파일 이름
+	CRIOFileName = "50-zim-mirrors.conf"
+	// ContainerdCertsDir containerd config_path 디렉터리 (certs.d/<host>/hosts.toml)
+	ContainerdCertsDir = "/etc/containerd/certs.d"
+)
+
+// 컨테이너 런타임
+const (
+	RuntimeCRIO       = "crio"
+	RuntimeContainerd = "containerd"
+)
+
+// Entry upstream 레지스트리 하나에 대한 미러 설정
+type Entry struct {
+	// Upstream 원본 레지스트리 호스트 (예: docker.io)
+	Upstream string
+	// Mirror 미러 위치 host[/path] (예: registry.internal/docker.io)
+	Mirror string
+	// Pulls 풀 이벤트에서 관찰된 풀 수 (매핑만 있고 관찰되지 않았으면 0)
+	Pulls int
+	// Insecure 미러가 평문 HTTP로 서비스됨 (CRI-O insecure = true, containerd http:// 호스트)
+	Insecure bool
+}
+
+// MirrorHost 미러 위치의 호스트
+func (e Entry) MirrorHost() string {
+	host, _, _ := strings.Cut(e.Mirror, "/")
+	return host
+}
+
+// MirrorPath 미러 위치의 저장소 경로 접두사 (없으면 빈 문자열)
+func (e Entry) MirrorPath() string {
+	_, prefix, _ := strings.Cut(e.Mirror, "/")
+	return prefix
+}
+
+// ParseMapping "upstream=mirror" 목록을 upstream 호스트별 미러 위치로 변환
+func ParseMapping(values []string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	for _, value := range values {
+		upstream, location, ok := strings.Cut(strings.TrimSpace(value), "=")
+		if !ok || upstream == "" || location == "" {
+			return nil, fmt.Errorf("invalid mirror mapping %q: expected upstream=mirror", value)
+		}
+		mapping[registry.NormalizeRegistry(upstream)] = strings.Trim(location, "/")
+	}
+	return mapping, nil
+}
+
+// Options 미러 설정 계산 옵션
+type Options struct {
+	// Mapping upstream 호스트별 미러 위치 (Target보다 우선)
+	Mapping map[string]string
+	// Target 매핑이 없는 레지스트리에 사용할 미러 레지스트리 host[/prefix] (zim mirror --to 와 같은 경로 규칙)
+	Target string
+	// Insecure 모든 미러가 평문 HTTP로 서비스됨
+	Insecure bool
+}
+
+// Entries 풀 이벤트에서 관찰된 레지스트리와 매핑으로 미러 설정 목록을 계산
+// 미러 위치를 정할 수 없는 관찰된 레지스트리는 unmapped로 반환
+func Entries(observed map[string]int, opts Options) ([]Entry, []string) {
+	upstreams := make(map[string]bool)
+	for host := range observed {
+		upstreams[host] = true
+	}
+	for host := range opts.Mapping {
+		upstreams[host] = true
+	}
+
+	targetHost := ""
+	if opts.Target != "" {
+		targetHost = registry.NormalizeRegistry(strings.SplitN(strings.Trim(opts.Target, "/"), "/", 2)[0])
+	}
+
+	var entries []Entry
+	var unmapped []string
+	for host := range upstreams {
+		location, ok := opts.Mapping[host]
+		switch {
+		case ok:
+		case opts.Target != "" && host != targetHost:
+			// zim mirror 가 복사한 저장소 경로의 접두사
+			ref := mirror.TargetReference(opts.Target, registry.Reference{Registry: host})
+			location = path.Join(ref.Registry, ref.Repository)
+		default:
+			if host != targetHost {
+				unmapped = append(unmapped, host)
+			}
+			continue
+		}
+		entries = append(entries, Entry{Upstream: host, Mirror: location, Pulls: observed[host], Insecure: opts.Insecure})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Pulls != entries[j].Pulls {
+			return entries[i].Pulls > entries[j].Pulls
+		}
+		return entries[i].Upstream < entries[j].Upstream
+	})
+	sort.Strings(unmapped)
+	return entries, unmapped
+}
@@ -0,0 +1,80 @@
+package mirrorconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEntries(t *testing.T) {
+	observed := map[string]int{"docker.io": 40, "quay.io": 12, "ghcr.io": 3, "registry.internal": 7}
+
+	tests := []struct {
+		name         string
+		opts         Options
+		wantEntries  []Entry
+		wantUnmapped []string
+	}{
+		{
+			name: "mapping only",
+			opts: Options{Mapping: map[string]string{"docker.io": "registry.internal/docker.io", "gcr.io": "cache.internal"}},
+			wantEntries: []Entry{
+				{Upstream: "docker.io", Mirror: "registry.internal/docker.io", Pulls: 40},
+				// 관찰되지 않은 매핑도 포함
+				{Upstream: "gcr.io", Mirror: "cache.internal"},
+			},
+			wantUnmapped: []string{"ghcr.io", "quay.io", "registry.internal"},
+		},
+		{
+			// 매핑이 --to보다 우선하고, 미러 레지스트리 자신은 미러링하지 않음
+			name: "mapping takes precedence over target",
+			opts: Options{
+				Mapping:  map[string]string{"docker.io": "cache.internal:5000"},
+				Target:   "registry.internal/mirror/",
+				Insecure: true,
+			},
+			wantEntries: []Entry{
+				{Upstream: "docker.io", Mirror: "cache.internal:5000", Pulls: 40, Insecure: true},
+				{Upstream: "quay.io", Mirror: "registry.internal/mirror/quay.io", Pulls: 12, Insecure: true},
+				{Upstream: "ghcr.io", Mirror: "registry.internal/mirror/ghcr.io", Pulls: 3, Insecure: true},
+			},
+		},
+		{
+			name: "target without prefix",
+			opts: Options{Target: "registry.internal"},
+			wantEntries: []Entry{
+				{Upstream: "docker.io", Mirror: "registry.internal/docker.io", Pulls: 40},
+				{Upstream: "quay.io", Mirror: "registry.internal/quay.io", Pulls: 12},
+				{Upstream: "ghcr.io", Mirror: "registry.internal/ghcr.io", Pulls: 3},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, unmapped := Entries(observed, tt.opts)
+			if !reflect.DeepEqual(entries, tt.wantEntries) {
+				t.Errorf("Entries() entries =\n%+v\nwant\n%+v", entries, tt.wantEntries)
+			}
+			if !reflect.DeepEqual(unmapped, tt.wantUnmapped) {
+				t.Errorf("Entries() unmapped = %v, want %v", unmapped, tt.wantUnmapped)
+			}
+		})
+	}
+}
+
+func TestParseMapping(t *testing.T) {
+	mapping, err := ParseMapping([]string{"index.docker.io=registry.internal/docker.io/", " quay.io=quay-cache.internal "})
+	if err != nil {
+		t.Fatalf("ParseMapping() error = %v", err)
+	}
+	want := map[string]string{"docker.io": "registry.internal/docker.io", "quay.io": "quay-cache.internal"}
+	if !reflect.DeepEqual(mapping, want) {
+		t.Errorf("ParseMapping() = %v, want %v", mapping, want)
+	}
+
+	for _, value := range []string{"docker.io", "=registry.internal", "docker.io="} {
+		if _, err := ParseMapping([]string{value}); err == nil {
+			t.Errorf("ParseMapping(%q) error = nil, want error", value)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package mirrorconfig
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// File 노드에 설치할 설정 파일
+type File struct {
+	// Path 노드의 절대 경로
+	Path    string
+	Runtime string
+	Content []byte
+}
+
+// Generate 런타임별 설정 파일을 생성 (runtimes가 비어 있으면 CRI-O와 containerd 모두)
+func Generate(entries []Entry, runtimes []string) []File {
+	if len(runtimes) == 0 {
+		runtimes = []string{RuntimeCRIO, RuntimeContainerd}
+	}
+
+	var files []File
+	for _, runtime := range runtimes {
+		switch runtime {
+		case RuntimeCRIO:
+			files = append(files, CRIOFile(entries))
+		case RuntimeContainerd:
+			files = append(files, ContainerdFiles(entries)...)
+		}
+	}
+	return files
+}
+
+// CRIOFile upstream마다 [[registry]]와 [[registry.mirror]]를 가진 registries.conf drop-in 파일을 생성
+func CRIOFile(entries []Entry) File {
+	var b strings.Builder
+	b.WriteString("# Generated by zim mirror-config. Reload CRI-O (systemctl reload crio) after changing this file.\n")
+	for _, entry := range entries {
+		fmt.Fprintf(&b, "\n# %s (%d pulls observed)\n", entry.Upstream, entry.Pulls)
+		b.WriteString("[[registry]]\n")
+		fmt.Fprintf(&b, "prefix = %q\n", entry.Upstream)
+		fmt.Fprintf(&b, "location = %q\n", entry.Upstream)
+		b.WriteString("\n[[registry.mirror]]\n")
+		fmt.Fprintf(&b, "location = %q\n", entry.Mirror)
+		if entry.Insecure {
+			b.WriteString("insecure = true\n")
+		}
+	}
+	return File{Path: path.Join(CRIODropInDir, CRIOFileName), Runtime: RuntimeCRIO, Content: []byte(b.String())}
+}
+
+// ContainerdFiles upstream마다 certs.d/<host>/hosts.toml 파일을 생성
+// 미러 위치에 경로가 있으면 override_path로 "/v2/<경로>"를 API 경로로 사용
+// Insecure이면 containerd가 평문 HTTP로 접속하도록 http:// 호스트를 사용 (skip_verify는 TLS 검증만 생략하므로 사용하지 않음)
+func ContainerdFiles(entries []Entry) []File {
+	var files []File
+	for _, entry := range entries {
+		scheme := "https://"
+		if entry.Insecure {
+			scheme = "http://"
+		}
+		hostURL := scheme + entry.MirrorHost()
+		if entry.MirrorPath() != "" {
+			hostURL += "/v2/" + entry.MirrorPath()
+		}
+
+		var b strings.Builder
+		b.WriteString("# Generated by zim mirror-config\n")
+		fmt.Fprintf(&b, "server = %q\n", registry.BaseURL(entry.Upstream))
+		fmt.Fprintf(&b, "\n[host.%q]\n", hostURL)
+		b.WriteString("  capabilities = [\"pull\", \"resolve\"]\n")
+		if entry.MirrorPath() != "" {
+			b.WriteString("  override_path = true\n")
+		}
+		files = append(files, File{
+			Path:    path.Join(ContainerdCertsDir, entry.Upstream, "hosts.toml"),
+			Runtime: RuntimeContainerd,
+			Content: []byte(b.String()),
+		})
+	}
+	return files
+}
+
+// WriteTree 파일을 dir 아래에 노드 경로 구조 그대로 기록하고 기록한 경로를 반환 (예: <dir>/etc/containerd/certs.d/...)
+func WriteTree(dir string, files []File) ([]string, error) {
+	var written []string
+	for _, file := range files {
+		target := filepath.Join(dir, filepath.FromSlash(file.Path))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return written, fmt.Errorf("failed to create directory for %s: %v", target, err)
+		}
+		if err := os.WriteFile(target, file.Content, 0644); err != nil {
+			return written, fmt.Errorf("failed to write %s: %v", target, err)
+		}
+		written = append(written, target)
+	}
+	return written, nil
+}
@@ -0,0 +1,93 @@
+package mirrorconfig
+
+import "testing"
+
+func TestCRIOFile(t *testing.T) {
+	file := CRIOFile([]Entry{
+		{Upstream: "docker.io", Mirror: "registry.internal/docker.io", Pulls: 40},
+		{Upstream: "quay.io", Mirror: "registry.internal:5000", Pulls: 12, Insecure: true},
+	})
+	if file.Path != "/etc/containers/registries.conf.d/50-zim-mirrors.conf" || file.Runtime != RuntimeCRIO {
+		t.Errorf("CRIOFile() path = %s, runtime = %s", file.Path, file.Runtime)
+	}
+
+	want := `# Generated by zim mirror-config. Reload CRI-O (systemctl reload crio) after changing this file.
+
+# docker.io (40 pulls observed)
+[[registry]]
+prefix = "docker.io"
+location = "docker.io"
+
+[[registry.mirror]]
+location = "registry.internal/docker.io"
+
+# quay.io (12 pulls observed)
+[[registry]]
+prefix = "quay.io"
+location = "quay.io"
+
+[[registry.mirror]]
+location = "registry.internal:5000"
+insecure = true
+`
+	if got := string(file.Content); got != want {
+		t.Errorf("CRIOFile() content =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestContainerdFiles(t *testing.T) {
+	tests := []struct {
+		entry    Entry
+		wantPath string
+		want     string
+	}{
+		{
+			// 미러 경로는 /v2/<경로>와 override_path로 사용, docker.io의 server는 registry-1.docker.io
+			entry:    Entry{Upstream: "docker.io", Mirror: "registry.internal/mirror/docker.io"},
+			wantPath: "/etc/containerd/certs.d/docker.io/hosts.toml",
+			want: `# Generated by zim mirror-config
+server = "https://registry-1.docker.io"
+
+[host."https://registry.internal/v2/mirror/docker.io"]
+  capabilities = ["pull", "resolve"]
+  override_path = true
+`,
+		},
+		{
+			// 평문 HTTP 미러는 skip_verify 대신 http:// 호스트
+			entry:    Entry{Upstream: "quay.io", Mirror: "registry.internal:5000", Insecure: true},
+			wantPath: "/etc/containerd/certs.d/quay.io/hosts.toml",
+			want: `# Generated by zim mirror-config
+server = "https://quay.io"
+
+[host."http://registry.internal:5000"]
+  capabilities = ["pull", "resolve"]
+`,
+		},
+		{
+			entry:    Entry{Upstream: "ghcr.io", Mirror: "ghcr-cache.internal"},
+			wantPath: "/etc/containerd/certs.d/ghcr.io/hosts.toml",
+			want: `# Generated by zim mirror-config
+server = "https://ghcr.io"
+
+[host."https://ghcr-cache.internal"]
+  capabilities = ["pull", "resolve"]
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.entry.Upstream, func(t *testing.T) {
+			files := ContainerdFiles([]Entry{tt.entry})
+			if len(files) != 1 {
+				t.Fatalf("ContainerdFiles() returned %d files, want 1", len(files))
+			}
+			if files[0].Path != tt.wantPath || files[0].Runtime != RuntimeContainerd {
+				t.Errorf("ContainerdFiles() path = %s, runtime = %s, want %s", files[0].Path, files[0].Runtime, tt.wantPath)
+			}
+			if got := string(files[0].Content); got != tt.want {
+				t.Errorf("ContainerdFiles() content =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package mirrorconfig
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+// PrintEntries 레지스트리별 미러 위치와 기록한 파일, 미러가 지정되지 않은 레지스트리 출력
+func PrintEntries(entries []Entry, unmapped []string, written []string) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "\nRegistry Mirror Configuration:\n")
+	fmt.Fprintln(w, "=======================================================================")
+	fmt.Fprintln(w, "No.\tUpstream\tMirror\tPulls Observed\tInsecure")
+	fmt.Fprintln(w, "-----------------------------------------------------------------------")
+	for i, entry := range entries {
+		insecure := "No"
+		if entry.Insecure {
+			insecure = "Yes"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\n", i+1, entry.Upstream, entry.Mirror, entry.Pulls, insecure)
+	}
+	fmt.Fprintln(w, "=======================================================================")
+	w.Flush()
+
+	// 요약 정보 출력
+	fmt.Printf("\nSummary:\n")
+	fmt.Printf("- Mirrored registries: %d\n", len(entries))
+	if len(unmapped) > 0 {
+		fmt.Printf("- Observed registries without a mirror: %d\n", len(unmapped))
+		for _, host := range unmapped {
+			fmt.Printf("  - %s\n", host)
+		}
+	}
+	fmt.Printf("- Files written: %d\n", len(written))
+	for _, path := range written {
+		fmt.Printf("  - %s\n", path)
+	}
+}
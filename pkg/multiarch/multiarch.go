@@ -0,0 +1,177 @@
+package multiarch
+
+import (
+	"context"
+	"sort"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// IncompatiblePod 이미지가 지원하지 않는 플랫폼의 노드에 스케줄된 Pod
+type IncompatiblePod struct {
+	Pod      string
+	Node     string
+	Platform string
+}
+
+// Finding 워크로드 이미지 하나의 플랫폼 호환성 확인 결과
+type Finding struct {
+	Namespace string
+	Workload  string
+	Image     string
+	Pods      int
+	// Platforms 이미지가 제공하는 플랫폼 ("os/arch")
+	Platforms []string
+	// Missing 실행 가능한 노드의 플랫폼 중 이미지가 제공하지 않는 플랫폼과 해당 노드 수
+	Missing map[string]int
+	// CompatibleNodes 실행 가능한 노드 중 이미지 플랫폼과 일치하는 노드 수
+	CompatibleNodes int
+	// EligibleNodes nodeSelector와 node affinity를 만족하는 노드 수
+	EligibleNodes int
+	// Incompatible 지원하지 않는 플랫폼의 노드에 이미 스케줄된 Pod
+	Incompatible []IncompatiblePod
+	// Error 매니페스트 조회에 실패한 경우의 오류 메시지
+	Error string
+}
+
+// HasIssue 누락된 플랫폼 또는 호환되지 않는 Pod가 있는지 확인
+func (f Finding) HasIssue() bool {
+	return len(f.Missing) > 0 || len(f.Incompatible) > 0
+}
+
+// imagePlatforms 이미지 참조별 플랫폼 조회 결과
+type imagePlatforms struct {
+	platforms map[string]bool
+	err       error
+}
+
+// Checker 레지스트리 매니페스트 인덱스로 이미지가 제공하는 플랫폼을 확인
+type Checker struct {
+	Client *registry.Client
+	images map[string]*imagePlatforms
+}
+
+// NewChecker 레지스트리 클라이언트로 Checker를 생성
+func NewChecker(client *registry.Client) *Checker {
+	return &Checker{Client: client, images: make(map[string]*imagePlatforms)}
+}
+
+// Check 워크로드 이미지마다 실행 가능한 노드의 플랫폼을 모두 지원하는지와 스케줄된 노드와 호환되는지 확인
+func (c *Checker) Check(ctx context.Context, nodes []kubernetes.NodePlatform, placements []kubernetes.PodPlacement) []Finding {
+	nodePlatforms := make(map[string]string, len(nodes))
+	for _, node := range nodes {
+		nodePlatforms[node.Name] = node.Platform()
+	}
+
+	type groupKey struct{ namespace, workload, image string }
+	type group struct {
+		finding  *Finding
+		eligible map[string]bool
+	}
+	groups := make(map[groupKey]*group)
+	var keys []groupKey
+
+	for _, placement := range placements {
+		for _, image := range placement.Images {
+			key := groupKey{placement.Namespace, placement.Workload, image}
+			g, ok := groups[key]
+			if !ok {
+				g = &group{
+					finding:  &Finding{Namespace: placement.Namespace, Workload: placement.Workload, Image: image, Missing: make(map[string]int)},
+					eligible: make(map[string]bool),
+				}
+				groups[key] = g
+				keys = append(keys, key)
+			}
+			g.finding.Pods++
+			for _, node := range placement.EligibleNodes {
+				g.eligible[node] = true
+			}
+
+			supported := c.platforms(ctx, image)
+			if supported.err != nil {
+				g.finding.Error = supported.err.Error()
+				continue
+			}
+			if placement.Node == "" {
+				continue
+			}
+			if platform, ok := nodePlatforms[placement.Node]; ok && !supported.platforms[platform] {
+				g.finding.Incompatible = append(g.finding.Incompatible, IncompatiblePod{
+					Pod:      placement.Pod,
+					Node:     placement.Node,
+					Platform: platform,
+				})
+			}
+		}
+	}
+
+	findings := make([]Finding, 0, len(keys))
+	for _, key := range keys {
+		g := groups[key]
+		finding := g.finding
+		supported := c.platforms(ctx, finding.Image)
+		if supported.err == nil {
+			for platform := range supported.platforms {
+				finding.Platforms = append(finding.Platforms, platform)
+			}
+			sort.Strings(finding.Platforms)
+			finding.EligibleNodes = len(g.eligible)
+			for node := range g.eligible {
+				platform := nodePlatforms[node]
+				if supported.platforms[platform] {
+					finding.CompatibleNodes++
+				} else {
+					finding.Missing[platform]++
+				}
+			}
+		}
+		findings = append(findings, *finding)
+	}
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].Namespace != findings[j].Namespace {
+			return findings[i].Namespace < findings[j].Namespace
+		}
+		if findings[i].Workload != findings[j].Workload {
+			return findings[i].Workload < findings[j].Workload
+		}
+		return findings[i].Image < findings[j].Image
+	})
+	return findings
+}
+
+// platforms 이미지가 제공하는 "os/arch" 플랫폼 집합을 조회 (결과는 캐시)
+func (c *Checker) platforms(ctx context.Context, image string) *imagePlatforms {
+	if result, ok := c.images[image]; ok {
+		return result
+	}
+	result := &imagePlatforms{platforms: make(map[string]bool)}
+	c.images[image] = result
+
+	ref, err := registry.ParseReference(image)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	manifest, err := c.Client.GetManifest(ctx, ref)
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	// 단일 매니페스트는 config에 기록된 플랫폼 하나만 지원
+	if !manifest.IsIndex() {
+		config, err := c.Client.GetConfig(ctx, ref, manifest)
+		if err != nil {
+			result.err = err
+			return result
+		}
+		result.platforms[config.OS+"/"+config.Architecture] = true
+		return result
+	}
+	for _, platform := range registry.IndexPlatforms(manifest) {
+		result.platforms[platform.OS+"/"+platform.Architecture] = true
+	}
+	return result
+}
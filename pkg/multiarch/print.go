@@ -0,0 +1,112 @@
+package multiarch
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+)
+
+// PrintFindings 노드 플랫폼 구성, 플랫폼이 누락된 이미지, 호환되지 않는 노드에 스케줄된 Pod 출력
+func PrintFindings(nodes []kubernetes.NodePlatform, findings []Finding, all bool) {
+	// 노드 플랫폼별 노드 수
+	nodeCounts := make(map[string]int)
+	for _, node := range nodes {
+		nodeCounts[node.Platform()]++
+	}
+	var platforms []string
+	for platform := range nodeCounts {
+		platforms = append(platforms, platform)
+	}
+	sort.Strings(platforms)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "\nNode Platforms:\n")
+	fmt.Fprintln(w, "Platform\tNodes")
+	fmt.Fprintln(w, "-----------------------------------------------------------------------")
+	for _, platform := range platforms {
+		fmt.Fprintf(w, "%s\t%d\n", platform, nodeCounts[platform])
+	}
+	w.Flush()
+
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "\nMulti-Architecture Compatibility:\n")
+	fmt.Fprintln(w, "=======================================================================")
+	fmt.Fprintln(w, "No.\tNamespace\tWorkload\tImage\tImage Platforms\tMissing Platforms\tCompatible Nodes")
+	fmt.Fprintln(w, "-----------------------------------------------------------------------")
+	no := 0
+	for _, finding := range findings {
+		if !all && !finding.HasIssue() {
+			continue
+		}
+		no++
+		imagePlatforms := strings.Join(finding.Platforms, ",")
+		if finding.Error != "" {
+			imagePlatforms = "unknown"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%d/%d\n", no, finding.Namespace, finding.Workload, finding.Image,
+			imagePlatforms, formatMissing(finding.Missing), finding.CompatibleNodes, finding.EligibleNodes)
+	}
+	fmt.Fprintln(w, "=======================================================================")
+	w.Flush()
+
+	// 이미 호환되지 않는 노드에 스케줄된 Pod
+	var incompatible int
+	for _, finding := range findings {
+		incompatible += len(finding.Incompatible)
+	}
+	if incompatible > 0 {
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
+		fmt.Fprintf(w, "\nPods Scheduled on Incompatible Nodes:\n")
+		fmt.Fprintln(w, "Namespace\tPod\tNode\tNode Platform\tImage")
+		fmt.Fprintln(w, "-----------------------------------------------------------------------")
+		for _, finding := range findings {
+			for _, pod := range finding.Incompatible {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", finding.Namespace, pod.Pod, pod.Node, pod.Platform, finding.Image)
+			}
+		}
+		w.Flush()
+	}
+
+	// 요약 정보 출력
+	var missing, unschedulable int
+	for _, finding := range findings {
+		if len(finding.Missing) > 0 {
+			missing++
+		}
+		if finding.EligibleNodes > 0 && finding.CompatibleNodes == 0 && finding.Error == "" {
+			unschedulable++
+		}
+	}
+	fmt.Printf("\nSummary:\n")
+	fmt.Printf("- Node platforms: %d (%d nodes)\n", len(platforms), len(nodes))
+	fmt.Printf("- Workload images checked: %d\n", len(findings))
+	fmt.Printf("- Images missing a platform of an eligible node: %d\n", missing)
+	fmt.Printf("- Images with no compatible eligible node: %d\n", unschedulable)
+	fmt.Printf("- Pods on incompatible nodes: %d\n", incompatible)
+	for _, finding := range findings {
+		if finding.Error != "" {
+			fmt.Printf("  - %s: %s\n", finding.Image, finding.Error)
+		}
+	}
+}
+
+// formatMissing 누락된 플랫폼을 "linux/arm64 (3 nodes)" 형식으로 표시
+func formatMissing(missing map[string]int) string {
+	if len(missing) == 0 {
+		return "-"
+	}
+	var platforms []string
+	for platform := range missing {
+		platforms = append(platforms, platform)
+	}
+	sort.Strings(platforms)
+	parts := make([]string, 0, len(platforms))
+	for _, platform := range platforms {
+		parts = append(parts, fmt.Sprintf("%s (%d nodes)", platform, missing[platform]))
+	}
+	return strings.Join(parts, ",")
+}
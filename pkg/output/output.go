@@ -0,0 +1,132 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Format 결과 출력 형식
+type Format string
+
+// 지원하는 출력 형식
+const (
+	FormatTable    Format = "table"
+	FormatJSON     Format = "json"
+	FormatYAML     Format = "yaml"
+	FormatCSV      Format = "csv"
+	FormatMarkdown Format = "markdown"
+)
+
+// Formats 플래그 도움말에 표시할 형식 목록
+const Formats = "table, json, yaml, csv, markdown"
+
+// ParseFormat 출력 형식 문자열을 확인 (빈 문자열은 table)
+func ParseFormat(value string) (Format, error) {
+	switch format := Format(strings.ToLower(strings.TrimSpace(value))); format {
+	case "":
+		return FormatTable, nil
+	case FormatTable, FormatJSON, FormatYAML, FormatCSV, FormatMarkdown:
+		return format, nil
+	case "md":
+		return FormatMarkdown, nil
+	case "yml":
+		return FormatYAML, nil
+	}
+	return "", fmt.Errorf("unknown output format %q: expected one of %s", value, Formats)
+}
+
+// Table CSV와 Markdown으로 출력할 표 (값은 단위 없이 기계가 읽을 수 있는 형태)
+type Table struct {
+	Title  string
+	Header []string
+	Rows   [][]string
+}
+
+// Render 구조화된 형식(json, yaml)은 data를, 표 형식(csv, markdown)은 tables를 출력
+// CSV는 헤더가 하나인 표 하나만 출력할 수 있으므로 tables가 하나가 아니면 오류를 반환
+// table 형식은 각 패키지의 Print 함수가 담당하므로 오류를 반환
+func Render(w io.Writer, format Format, data interface{}, tables []Table) error {
+	switch format {
+	case FormatJSON:
+		return WriteJSON(w, data)
+	case FormatYAML:
+		return WriteYAML(w, data)
+	case FormatCSV:
+		if len(tables) != 1 {
+			return fmt.Errorf("csv output needs exactly one table, got %d", len(tables))
+		}
+		return WriteCSV(w, tables[0])
+	case FormatMarkdown:
+		return WriteMarkdown(w, tables)
+	}
+	return fmt.Errorf("output format %q is not rendered by output.Render", format)
+}
+
+// WriteJSON 들여쓰기된 JSON으로 출력
+func WriteJSON(w io.Writer, data interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(data); err != nil {
+		return fmt.Errorf("failed to encode JSON: %v", err)
+	}
+	return nil
+}
+
+// WriteYAML JSON 필드 이름과 같은 키의 YAML로 출력
+func WriteYAML(w io.Writer, data interface{}) error {
+	encoded, err := yaml.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode YAML: %v", err)
+	}
+	_, err = w.Write(encoded)
+	return err
+}
+
+// WriteCSV 표 하나를 헤더 행과 함께 CSV로 출력
+func WriteCSV(w io.Writer, table Table) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(table.Header); err != nil {
+		return fmt.Errorf("failed to write CSV: %v", err)
+	}
+	if err := writer.WriteAll(table.Rows); err != nil {
+		return fmt.Errorf("failed to write CSV: %v", err)
+	}
+	return writer.Error()
+}
+
+// WriteMarkdown 표를 제목이 있는 Markdown 표로 출력 (위키 붙여넣기용)
+func WriteMarkdown(w io.Writer, tables []Table) error {
+	for i, table := range tables {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		if table.Title != "" {
+			fmt.Fprintf(w, "### %s\n\n", table.Title)
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join(escapeMarkdown(table.Header), " | "))
+		separators := make([]string, len(table.Header))
+		for i := range separators {
+			separators[i] = "---"
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join(separators, " | "))
+		for _, row := range table.Rows {
+			fmt.Fprintf(w, "| %s |\n", strings.Join(escapeMarkdown(row), " | "))
+		}
+	}
+	return nil
+}
+
+// escapeMarkdown 셀 안의 '|'와 줄바꿈을 Markdown 표에서 깨지지 않게 변환
+func escapeMarkdown(cells []string) []string {
+	escaped := make([]string, len(cells))
+	replacer := strings.NewReplacer("|", "\\|", "\n", " ")
+	for i, cell := range cells {
+		escaped[i] = replacer.Replace(cell)
+	}
+	return escaped
+}
@@ -0,0 +1,85 @@
+package pullcache
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/ratelimit"
+	"github.com/suslmk-lee/zim-image-management/pkg/units"
+)
+
+// PrintRecommendations 레지스트리별 캐시 우선순위와 미러링 효과가 큰 상위 이미지 출력
+func PrintRecommendations(registries []RegistryCandidate, images []ImageCandidate, since, top int) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "\nPull-Through Cache Recommendations (Last %d hours):\n", since)
+	fmt.Fprintln(w, "=======================================================================")
+	fmt.Fprintln(w, "No.\tRegistry\tImages\tPulls/Day\tWith Cache\tSaved/Day\tData Saved/Day\tQuota Use\tPriority")
+	fmt.Fprintln(w, "-----------------------------------------------------------------------")
+	for i, r := range registries {
+		quotaUse := "-"
+		if r.DailyQuota > 0 {
+			quotaUse = fmt.Sprintf("%.0f%%", r.QuotaUsage()*100)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%d\t%.1f\t%.1f\t%.1f\t%s\t%s\t%s\n", i+1, r.Registry, r.Images, r.PullsPerDay,
+			r.UpstreamPerDay, r.SavedPerDay, formatBytes(r.BytesSavedPerDay), quotaUse, r.Priority)
+	}
+	fmt.Fprintln(w, "=======================================================================")
+	w.Flush()
+
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "\nTop Images to Mirror:\n")
+	fmt.Fprintln(w, "Rank\tImage\tPulls\tTags\tSaved/Day\tImage Size\tData Saved/Day\tEst. Cost Saved/Day")
+	fmt.Fprintln(w, "-----------------------------------------------------------------------")
+	rank := 0
+	for _, image := range images {
+		if rank == top {
+			break
+		}
+		if image.SavedPerDay <= 0 {
+			continue
+		}
+		rank++
+		cost := "-"
+		if image.BytesSavedPerDay > 0 {
+			cost = fmt.Sprintf("$%.2f", image.CostSavedPerDay)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%d\t%d\t%.1f\t%s\t%s\t%s\n", rank, image.Image, image.Pulls, image.References,
+			image.SavedPerDay, formatBytes(image.Size), formatBytes(image.BytesSavedPerDay), cost)
+	}
+	w.Flush()
+
+	// 요약 정보 출력
+	var saved, upstream, current float64
+	var savedBytes int64
+	var savedCost float64
+	for _, r := range registries {
+		saved += r.SavedPerDay
+		upstream += r.UpstreamPerDay
+		current += r.PullsPerDay
+		savedBytes += r.BytesSavedPerDay
+		savedCost += r.CostSavedPerDay
+	}
+	fmt.Printf("\nSummary:\n")
+	fmt.Printf("- Upstream pulls per day: %.1f now, %.1f with a pull-through cache\n", current, upstream)
+	fmt.Printf("- Estimated upstream pulls saved per day: %.1f\n", saved)
+	if savedBytes > 0 {
+		fmt.Printf("- Estimated data saved per day: %s ($%.2f)\n", units.FormatBytes(savedBytes), savedCost)
+	}
+	for _, r := range registries {
+		if r.Quota == nil {
+			continue
+		}
+		fmt.Printf("- %s quota (%s): %d/%d remaining per %s, %.0f pulls/day allowed; %.1f/day now, %.1f/day with cache\n",
+			r.Registry, r.Quota.Identity, r.Quota.Remaining, r.Quota.Limit, ratelimit.FormatWindow(r.Quota.Window),
+			r.DailyQuota, r.PullsPerDay, r.UpstreamPerDay)
+	}
+}
+
+// formatBytes 바이트 수를 표시 (0이면 "-")
+func formatBytes(value int64) string {
+	if value <= 0 {
+		return "-"
+	}
+	return units.FormatBytes(value)
+}
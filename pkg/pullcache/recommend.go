@@ -0,0 +1,196 @@
+package pullcache
+
+import (
+	"sort"
+	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/ratelimit"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// 캐시 도입 우선순위
+const (
+	PriorityHigh   = "High"
+	PriorityMedium = "Medium"
+	PriorityLow    = "Low"
+)
+
+// 우선순위 기준
+const (
+	// quotaUsageHigh 하루 풀 수가 일일 quota에서 차지하는 비율이 이 값 이상이면 High
+	quotaUsageHigh = 0.5
+	// quotaRemainingLow 현재 윈도우에 남은 quota 비율이 이 값 이하이면 High
+	quotaRemainingLow = 0.2
+	// savedPullsMedium 하루에 절약되는 upstream 풀 수가 이 값 이상이면 Medium
+	savedPullsMedium = 10
+	// savedBytesMedium 하루에 절약되는 전송량이 이 값(1 GB) 이상이면 Medium
+	savedBytesMedium = 1e9
+)
+
+var priorityOrder = map[string]int{PriorityHigh: 0, PriorityMedium: 1, PriorityLow: 2}
+
+// Options 추천 계산 옵션
+type Options struct {
+	// Since 풀 이벤트 조회 기간 (시간)
+	Since int
+	// Sizes 이미지별 크기 (없으면 전송량 절감을 계산하지 않음)
+	Sizes kubernetes.ImageSizes
+	// CostPerGB 레지스트리별 GB당 egress 비용, "default" 키는 나머지 레지스트리에 적용
+	CostPerGB map[string]float64
+	// Quotas 레지스트리 rate limit 조회 결과 (docker.io 풀 제한 등)
+	Quotas []ratelimit.Quota
+}
+
+// costPerGB 레지스트리에 적용할 GB당 비용
+func (o Options) costPerGB(host string) float64 {
+	if cost, ok := o.CostPerGB[host]; ok {
+		return cost
+	}
+	return o.CostPerGB["default"]
+}
+
+// ImageCandidate 캐시했을 때 upstream 풀이 줄어드는 이미지
+type ImageCandidate struct {
+	Image    string
+	Registry string
+	Pulls    int
+	// References 조회 기간 동안 풀한 서로 다른 태그/digest 수 (캐시 사용 시 upstream 풀 수)
+	References int
+	Size       int64
+	// SavedPerDay 캐시로 줄어드는 하루 upstream 풀 수
+	SavedPerDay      float64
+	BytesSavedPerDay int64
+	CostSavedPerDay  float64
+}
+
+// RegistryCandidate 레지스트리 단위의 캐시 효과와 quota 사용률
+type RegistryCandidate struct {
+	Registry string
+	Pulls    int
+	Images   int
+	// PullsPerDay 현재 하루 upstream 풀 수
+	PullsPerDay float64
+	// UpstreamPerDay 캐시 사용 시 예상 하루 upstream 풀 수
+	UpstreamPerDay   float64
+	SavedPerDay      float64
+	BytesSavedPerDay int64
+	CostSavedPerDay  float64
+	// Quota 레지스트리의 풀 제한 (없으면 nil)
+	Quota *ratelimit.Quota
+	// DailyQuota 제한 윈도우를 하루로 환산한 풀 수 (제한이 없으면 0)
+	DailyQuota float64
+	Priority   string
+}
+
+// QuotaUsage 현재 하루 풀 수가 일일 quota에서 차지하는 비율 (제한이 없으면 0)
+func (r RegistryCandidate) QuotaUsage() float64 {
+	if r.DailyQuota == 0 {
+		return 0
+	}
+	return r.PullsPerDay / r.DailyQuota
+}
+
+// Recommend 풀 이벤트를 이미지와 레지스트리별로 집계하여 pull-through 캐시로 절약되는 upstream 풀을 추정
+// 캐시는 조회 기간 동안 받은 태그/digest를 보관한다고 가정하여, 참조마다 첫 풀만 upstream으로 계산
+func Recommend(pullEvents []string, opts Options) ([]RegistryCandidate, []ImageCandidate) {
+	days := float64(opts.Since) / 24
+	if days <= 0 {
+		days = 1
+	}
+
+	images := make(map[string]*ImageCandidate)
+	for reference, count := range kubernetes.CountPullsByReference(pullEvents) {
+		ref, err := registry.ParseReference(reference)
+		if err != nil {
+			continue
+		}
+		candidate, ok := images[ref.Name()]
+		if !ok {
+			candidate = &ImageCandidate{Image: ref.Name(), Registry: ref.Registry, Size: opts.Sizes[ref.Name()].Bytes}
+			images[ref.Name()] = candidate
+		}
+		candidate.Pulls += count
+		candidate.References++
+	}
+
+	registries := make(map[string]*RegistryCandidate)
+	var imageList []ImageCandidate
+	for _, candidate := range images {
+		candidate.SavedPerDay = float64(candidate.Pulls-candidate.References) / days
+		candidate.BytesSavedPerDay = int64(candidate.SavedPerDay * float64(candidate.Size))
+		candidate.CostSavedPerDay = float64(candidate.BytesSavedPerDay) / 1e9 * opts.costPerGB(candidate.Registry)
+		imageList = append(imageList, *candidate)
+
+		r, ok := registries[candidate.Registry]
+		if !ok {
+			r = &RegistryCandidate{Registry: candidate.Registry}
+			registries[candidate.Registry] = r
+		}
+		r.Pulls += candidate.Pulls
+		r.Images++
+		r.UpstreamPerDay += float64(candidate.References) / days
+		r.SavedPerDay += candidate.SavedPerDay
+		r.BytesSavedPerDay += candidate.BytesSavedPerDay
+		r.CostSavedPerDay += candidate.CostSavedPerDay
+	}
+
+	var registryList []RegistryCandidate
+	for _, r := range registries {
+		r.PullsPerDay = float64(r.Pulls) / days
+		if quota := pullQuota(opts.Quotas, r.Registry); quota != nil {
+			r.Quota = quota
+			r.DailyQuota = float64(quota.Limit)
+			if quota.Window > 0 {
+				r.DailyQuota = float64(quota.Limit) * float64(24*time.Hour) / float64(quota.Window)
+			}
+		}
+		r.Priority = priority(*r)
+		registryList = append(registryList, *r)
+	}
+
+	sort.Slice(registryList, func(i, j int) bool {
+		if priorityOrder[registryList[i].Priority] != priorityOrder[registryList[j].Priority] {
+			return priorityOrder[registryList[i].Priority] < priorityOrder[registryList[j].Priority]
+		}
+		if registryList[i].SavedPerDay != registryList[j].SavedPerDay {
+			return registryList[i].SavedPerDay > registryList[j].SavedPerDay
+		}
+		return registryList[i].Registry < registryList[j].Registry
+	})
+	sort.Slice(imageList, func(i, j int) bool {
+		if imageList[i].BytesSavedPerDay != imageList[j].BytesSavedPerDay {
+			return imageList[i].BytesSavedPerDay > imageList[j].BytesSavedPerDay
+		}
+		if imageList[i].SavedPerDay != imageList[j].SavedPerDay {
+			return imageList[i].SavedPerDay > imageList[j].SavedPerDay
+		}
+		return imageList[i].Image < imageList[j].Image
+	})
+	return registryList, imageList
+}
+
+// pullQuota 레지스트리의 풀 횟수 제한을 찾음 (제한이 확인된 것만)
+func pullQuota(quotas []ratelimit.Quota, host string) *ratelimit.Quota {
+	for i := range quotas {
+		if quotas[i].Registry == host && quotas[i].HasLimit() && quotas[i].Unit == "pulls" {
+			return &quotas[i]
+		}
+	}
+	return nil
+}
+
+// priority quota 압박, 절약되는 풀 수와 전송량으로 캐시 도입 우선순위를 결정
+func priority(r RegistryCandidate) string {
+	if r.SavedPerDay <= 0 {
+		return PriorityLow
+	}
+	if r.Quota != nil && (r.Quota.Throttled || r.QuotaUsage() >= quotaUsageHigh ||
+		float64(r.Quota.Remaining) <= float64(r.Quota.Limit)*quotaRemainingLow) {
+		return PriorityHigh
+	}
+	if r.SavedPerDay >= savedPullsMedium || r.BytesSavedPerDay >= savedBytesMedium {
+		return PriorityMedium
+	}
+	return PriorityLow
+}
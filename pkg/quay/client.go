@@ -0,0 +1,118 @@
+package quay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/httpclient"
+	"github.com/suslmk-lee/zim-image-management/pkg/ratelimit"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// DefaultURL Quay.io 엔드포인트
+const DefaultURL = "https://quay.io"
+
+// Client Quay 레지스트리 및 API 클라이언트
+type Client struct {
+	HTTPClient *http.Client
+	BaseURL    string
+	// Token Quay API OAuth 토큰 (비공개 저장소 통계 조회용, 비어 있으면 익명)
+	Token string
+}
+
+// RepositoryStat 일자별 저장소 풀 통계
+type RepositoryStat struct {
+	Date  string `json:"date"`
+	Count int64  `json:"count"`
+}
+
+// Repository 저장소 정보 중 통계 관련 필드
+type Repository struct {
+	Namespace string           `json:"namespace"`
+	Name      string           `json:"name"`
+	IsPublic  bool             `json:"is_public"`
+	Stats     []RepositoryStat `json:"stats"`
+}
+
+// ManifestProbe 매니페스트 조회 응답의 상태와 throttling 정보
+type ManifestProbe struct {
+	StatusCode int
+	Header     http.Header
+	RetryAfter time.Duration
+}
+
+// NewClient Quay.io를 사용하는 클라이언트를 생성 (httpClient가 nil이면 기본 클라이언트 사용)
+func NewClient(httpClient *http.Client, token string) *Client {
+	if httpClient == nil {
+		httpClient = httpclient.Default()
+	}
+	return &Client{HTTPClient: httpClient, BaseURL: DefaultURL, Token: token}
+}
+
+// TotalPulls 통계에 포함된 기간 전체의 풀 횟수 합계
+func (r *Repository) TotalPulls() int64 {
+	var total int64
+	for _, stat := range r.Stats {
+		total += stat.Count
+	}
+	return total
+}
+
+// GetRepository 저장소 정보와 풀 통계를 조회 (Quay API의 includeStats 사용)
+func (c *Client) GetRepository(ctx context.Context, repository string) (*Repository, error) {
+	repoURL := fmt.Sprintf("%s/api/v1/repository/%s?includeStats=true&includeTags=false", c.BaseURL, repository)
+	req, err := http.NewRequestWithContext(ctx, "GET", repoURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create repository request: %v", err)
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository %s: %v", repository, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("repository request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var repo Repository
+	if err := json.Unmarshal(body, &repo); err != nil {
+		return nil, fmt.Errorf("failed to decode repository response: %v", err)
+	}
+	return &repo, nil
+}
+
+// ProbeManifest 레지스트리 인증 후 매니페스트를 HEAD 요청하여 throttling 응답을 확인
+func (c *Client) ProbeManifest(ctx context.Context, image registry.Reference) (*ManifestProbe, error) {
+	authClient := registry.NewAuthClient(c.HTTPClient, nil)
+
+	manifestURL := fmt.Sprintf("%s/v2/%s/manifests/%s", c.BaseURL, image.Repository, image.Identifier())
+	req, err := http.NewRequestWithContext(ctx, "HEAD", manifestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create manifest request: %v", err)
+	}
+	req.Header.Set("Accept", registry.ManifestAccept)
+
+	resp, err := authClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query manifest %s: %v", image, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	probe := &ManifestProbe{StatusCode: resp.StatusCode, Header: resp.Header}
+	probe.RetryAfter = ratelimit.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	return probe, nil
+}
@@ -0,0 +1,104 @@
+package quay
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/ratelimit"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// RateLimitProvider Quay의 throttling 응답과 저장소 풀 통계를 ratelimit.Provider 형태로 제공
+type RateLimitProvider struct {
+	Client *Client
+	Images []registry.Reference
+}
+
+// Name 레지스트리 이름
+func (p *RateLimitProvider) Name() string {
+	return p.host()
+}
+
+// Matches 클라이언트에 설정된 Quay 호스트를 담당
+func (p *RateLimitProvider) Matches(host string) bool {
+	return registry.NormalizeRegistry(host) == p.host()
+}
+
+// host 클라이언트 BaseURL의 호스트 이름
+func (p *RateLimitProvider) host() string {
+	return registry.NormalizeRegistry(p.Client.BaseURL)
+}
+
+// Check 저장소별로 매니페스트 throttling 여부와 풀 통계를 조회
+func (p *RateLimitProvider) Check(ctx context.Context) ([]ratelimit.Quota, error) {
+	if len(p.Images) == 0 {
+		return nil, fmt.Errorf("no %s images to probe", p.host())
+	}
+
+	identity := "anonymous"
+	if p.Client.Token != "" {
+		identity = "oauth token"
+	}
+
+	// 같은 저장소의 여러 태그는 한 번만 조회
+	seen := make(map[string]bool)
+	var quotas []ratelimit.Quota
+	var firstErr error
+	failed := 0
+	for _, image := range p.Images {
+		if seen[image.Repository] {
+			continue
+		}
+		seen[image.Repository] = true
+
+		probe, err := p.Client.ProbeManifest(ctx, image)
+		if err != nil {
+			// 한 저장소의 실패로 나머지 저장소 결과를 버리지 않음
+			if firstErr == nil {
+				firstErr = err
+			}
+			failed++
+			quotas = append(quotas, ratelimit.Quota{
+				Registry: p.host(),
+				Resource: image.Repository,
+				Unit:     "pulls",
+				Identity: identity,
+				Note:     "error: " + err.Error(),
+			})
+			continue
+		}
+
+		quota, _ := ratelimit.ParseHeaders(probe.Header)
+		quota.Registry = p.host()
+		quota.Resource = image.Repository
+		quota.Unit = "pulls"
+		quota.Identity = identity
+		quota.Throttled = probe.StatusCode == 429
+		if probe.RetryAfter > 0 {
+			quota.Reset = time.Now().Add(probe.RetryAfter)
+		}
+
+		notes := []string{fmt.Sprintf("HTTP %d", probe.StatusCode)}
+		if quota.Throttled {
+			notes = append(notes, "throttled")
+		}
+
+		// 저장소 통계는 공개 저장소 또는 토큰 권한이 있는 경우에만 제공됨
+		if repo, err := p.Client.GetRepository(ctx, image.Repository); err == nil {
+			if len(repo.Stats) > 0 {
+				notes = append(notes, fmt.Sprintf("%d pulls in last %d days", repo.TotalPulls(), len(repo.Stats)))
+			}
+		} else {
+			notes = append(notes, "pull stats unavailable")
+		}
+		quota.Note = strings.Join(notes, "; ")
+
+		quotas = append(quotas, quota)
+	}
+	if failed == len(seen) {
+		return nil, firstErr
+	}
+	return quotas, nil
+}
@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// GenericProvider 전용 Provider가 없는 OCI 레지스트리의 매니페스트 응답 헤더에서 제한 정보를 확인
+type GenericProvider struct {
+	HTTPClient  *http.Client
+	Host        string
+	Image       registry.Reference
+	Credentials registry.CredentialFunc
+}
+
+// Name 레지스트리 호스트 이름
+func (p *GenericProvider) Name() string {
+	return p.Host
+}
+
+// Matches 같은 호스트인지 확인
+func (p *GenericProvider) Matches(host string) bool {
+	return registry.NormalizeRegistry(host) == p.Host
+}
+
+// Check 대표 이미지의 매니페스트를 HEAD 요청하여 응답 헤더의 제한 정보를 수집
+func (p *GenericProvider) Check(ctx context.Context) ([]Quota, error) {
+	client := registry.NewAuthClient(p.HTTPClient, p.Credentials)
+
+	manifestURL := fmt.Sprintf("%s/v2/%s/manifests/%s", registry.BaseURL(p.Host), p.Image.Repository, p.Image.Identifier())
+	req, err := http.NewRequestWithContext(ctx, "HEAD", manifestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create manifest request: %v", err)
+	}
+	req.Header.Set("Accept", registry.ManifestAccept)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %v", p.Host, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	quota, found := ParseHeaders(resp.Header)
+	quota.Registry = p.Host
+	quota.Resource = "manifest requests"
+	quota.Identity = "anonymous"
+	if p.Credentials != nil && !p.Credentials(p.Host).IsAnonymous() {
+		quota.Identity = "authenticated"
+	}
+	quota.Throttled = resp.StatusCode == http.StatusTooManyRequests
+
+	switch {
+	case quota.Throttled:
+		quota.Note = "throttled (HTTP 429)"
+	case resp.StatusCode >= 300:
+		quota.Note = fmt.Sprintf("HTTP %d for %s", resp.StatusCode, p.Image)
+	case !found:
+		quota.Note = "no rate limit headers returned"
+	}
+
+	return []Quota{quota}, nil
+}
@@ -0,0 +1,88 @@
+package ratelimit
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseHeaders RateLimit-* (Docker Hub, IETF 초안) 및 X-RateLimit-* 응답 헤더에서 제한 정보를 추출
+func ParseHeaders(header http.Header) (Quota, bool) {
+	var quota Quota
+	found := false
+
+	for _, prefix := range []string{"RateLimit-", "X-RateLimit-"} {
+		if value := header.Get(prefix + "Limit"); value != "" {
+			quota.Limit, quota.Window = parseLimitValue(value)
+			found = true
+		}
+		if value := header.Get(prefix + "Remaining"); value != "" {
+			quota.Remaining, _ = parseLimitValue(value)
+			found = true
+		}
+		if value := header.Get(prefix + "Reset"); value != "" {
+			quota.Reset = parseReset(value)
+		}
+		if found {
+			break
+		}
+	}
+
+	if source := header.Get("Docker-RateLimit-Source"); source != "" {
+		quota.Source = strings.Trim(source, "[]")
+		found = true
+	}
+	if quota.Reset.IsZero() && quota.Window > 0 {
+		quota.Reset = time.Now().Add(quota.Window)
+	}
+
+	return quota, found
+}
+
+// parseLimitValue "100;w=21600" 형식에서 제한 값과 윈도우를 추출
+func parseLimitValue(value string) (int64, time.Duration) {
+	parts := strings.Split(value, ";")
+	limit, _ := strconv.ParseInt(strings.TrimSpace(parts[0]), 10, 64)
+
+	var window time.Duration
+	for _, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, "w=") {
+			seconds, _ := strconv.Atoi(strings.TrimRight(strings.TrimPrefix(part, "w="), "[]"))
+			window = time.Duration(seconds) * time.Second
+		}
+	}
+	return limit, window
+}
+
+// parseReset 남은 초 또는 Unix timestamp 형식의 리셋 값을 시각으로 변환
+func parseReset(value string) time.Time {
+	seconds, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	// 10억보다 크면 Unix timestamp, 아니면 남은 초로 해석
+	if seconds > 1_000_000_000 {
+		return time.Unix(seconds, 0)
+	}
+	return time.Now().Add(time.Duration(seconds) * time.Second)
+}
+
+// ParseRetryAfter Retry-After 헤더 값을 대기 시간으로 변환 (RFC 9110의 초 단위 정수와 HTTP-date 형식 모두 허용, 해석할 수 없으면 0)
+func ParseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/units"
+)
+
+// PrintResults 모든 Provider의 조회 결과를 하나의 표로 출력
+func PrintResults(results []Result) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "\nRegistry Rate Limits:\n")
+	fmt.Fprintln(w, "=======================================================================")
+	fmt.Fprintln(w, "Registry\tResource\tLimit\tRemaining\tWindow\tReset Time\tSource\tIdentity\tNotes")
+	fmt.Fprintln(w, "-----------------------------------------------------------------------")
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Fprintf(w, "%s\t-\t-\t-\t-\t-\t-\t-\terror: %v\n", result.Provider, result.Err)
+			continue
+		}
+		for _, quota := range result.Quotas {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				quota.Registry,
+				quota.Resource,
+				formatAmount(quota, quota.Limit),
+				formatAmount(quota, quota.Remaining),
+				FormatWindow(quota.Window),
+				formatTime(quota.Reset),
+				orDash(quota.Source),
+				orDash(quota.Identity),
+				quota.Note)
+		}
+	}
+	fmt.Fprintln(w, "=======================================================================")
+	w.Flush()
+	fmt.Println()
+}
+
+// formatAmount 단위에 맞게 제한 값을 표시 (제한 정보가 없으면 "-")
+func formatAmount(quota Quota, value int64) string {
+	if !quota.HasLimit() {
+		return "-"
+	}
+	if quota.Unit == "bytes" {
+		return units.FormatBytes(value)
+	}
+	return strconv.FormatInt(value, 10)
+}
+
+// FormatWindow 윈도우 기간을 표시 (없으면 "-")
+func FormatWindow(window time.Duration) string {
+	if window <= 0 {
+		return "-"
+	}
+	if window%time.Hour == 0 {
+		return fmt.Sprintf("%dh", int(window.Hours()))
+	}
+	return window.String()
+}
+
+// formatTime 리셋 시각을 로컬 시간으로 표시 (없으면 "-")
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+// orDash 빈 문자열을 "-"로 표시
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,96 @@
+package ratelimit
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/output"
+)
+
+// Record Quota를 JSON/YAML로 출력하기 위한 안정적인 스키마 (필드 이름은 호환성을 위해 변경하지 않음)
+type Record struct {
+	Provider  string `json:"provider"`
+	Registry  string `json:"registry"`
+	Resource  string `json:"resource"`
+	Unit      string `json:"unit"`
+	Limit     int64  `json:"limit"`
+	Remaining int64  `json:"remaining"`
+	// WindowSeconds 제한 윈도우 길이 (모르면 0)
+	WindowSeconds int64 `json:"windowSeconds"`
+	// Reset 제한이 초기화되는 시각 (모르면 생략)
+	Reset     *time.Time `json:"reset,omitempty"`
+	Source    string     `json:"source,omitempty"`
+	Identity  string     `json:"identity,omitempty"`
+	Throttled bool       `json:"throttled"`
+	Note      string     `json:"note,omitempty"`
+	// Error 조회 실패 시 오류 메시지 (나머지 값은 비어 있음)
+	Error string `json:"error,omitempty"`
+}
+
+// NewRecord Quota를 출력용 Record로 변환
+func NewRecord(provider string, quota Quota) Record {
+	record := Record{
+		Provider:      provider,
+		Registry:      quota.Registry,
+		Resource:      quota.Resource,
+		Unit:          quota.Unit,
+		Limit:         quota.Limit,
+		Remaining:     quota.Remaining,
+		WindowSeconds: int64(quota.Window / time.Second),
+		Source:        quota.Source,
+		Identity:      quota.Identity,
+		Throttled:     quota.Throttled,
+		Note:          quota.Note,
+	}
+	if !quota.Reset.IsZero() {
+		reset := quota.Reset.UTC()
+		record.Reset = &reset
+	}
+	return record
+}
+
+// Records Provider 조회 결과를 Record 목록으로 변환 (실패한 Provider는 Error만 있는 Record 하나)
+func Records(results []Result) []Record {
+	records := []Record{}
+	for _, result := range results {
+		if result.Err != nil {
+			records = append(records, Record{Provider: result.Provider, Registry: result.Provider, Error: result.Err.Error()})
+			continue
+		}
+		for _, quota := range result.Quotas {
+			records = append(records, NewRecord(result.Provider, quota))
+		}
+	}
+	return records
+}
+
+// RecordsTable Record 목록을 CSV/Markdown 표로 변환
+func RecordsTable(records []Record) output.Table {
+	table := output.Table{
+		Title: "Registry Rate Limits",
+		Header: []string{"provider", "registry", "resource", "unit", "limit", "remaining", "window_seconds",
+			"reset", "source", "identity", "throttled", "note", "error"},
+	}
+	for _, record := range records {
+		reset := ""
+		if record.Reset != nil {
+			reset = record.Reset.Format(time.RFC3339)
+		}
+		table.Rows = append(table.Rows, []string{
+			record.Provider,
+			record.Registry,
+			record.Resource,
+			record.Unit,
+			strconv.FormatInt(record.Limit, 10),
+			strconv.FormatInt(record.Remaining, 10),
+			strconv.FormatInt(record.WindowSeconds, 10),
+			reset,
+			record.Source,
+			record.Identity,
+			strconv.FormatBool(record.Throttled),
+			record.Note,
+			record.Error,
+		})
+	}
+	return table
+}
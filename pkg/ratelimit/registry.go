@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// ProviderRegistry 레지스트리 호스트별 Provider를 관리
+type ProviderRegistry struct {
+	providers []Provider
+	// Fallback 등록된 Provider가 없는 레지스트리에 사용할 Provider를 생성 (nil이면 건너뜀)
+	Fallback func(registry string) Provider
+}
+
+// NewProviderRegistry 빈 ProviderRegistry를 생성
+func NewProviderRegistry() *ProviderRegistry {
+	return &ProviderRegistry{}
+}
+
+// Register Provider를 등록 (먼저 등록된 Provider가 우선)
+func (r *ProviderRegistry) Register(p Provider) {
+	r.providers = append(r.providers, p)
+}
+
+// Providers 등록된 모든 Provider를 반환
+func (r *ProviderRegistry) Providers() []Provider {
+	return r.providers
+}
+
+// ForRegistries 레지스트리 호스트 목록에 해당하는 Provider를 중복 없이 반환
+func (r *ProviderRegistry) ForRegistries(registries []string) []Provider {
+	sorted := append([]string{}, registries...)
+	sort.Strings(sorted)
+
+	seen := make(map[Provider]bool)
+	var selected []Provider
+	for _, host := range sorted {
+		var matched Provider
+		for _, p := range r.providers {
+			if p.Matches(host) {
+				matched = p
+				break
+			}
+		}
+		if matched == nil && r.Fallback != nil {
+			matched = r.Fallback(host)
+		}
+		if matched == nil || seen[matched] {
+			continue
+		}
+		seen[matched] = true
+		selected = append(selected, matched)
+	}
+	return selected
+}
+
+// CheckAll Provider들을 동시에 조회하고 입력 순서대로 결과를 반환
+func CheckAll(ctx context.Context, providers []Provider) []Result {
+	results := make([]Result, len(providers))
+
+	var wg sync.WaitGroup
+	for i, p := range providers {
+		wg.Add(1)
+		go func(i int, p Provider) {
+			defer wg.Done()
+			quotas, err := p.Check(ctx)
+			results[i] = Result{Provider: p.Name(), Quotas: quotas, Err: err}
+		}(i, p)
+	}
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,45 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Quota 레지스트리 또는 API의 사용량 제한 정보 (Limit이 0이면 제한 정보를 알 수 없음)
+type Quota struct {
+	Registry  string
+	Resource  string
+	Limit     int64
+	Remaining int64
+	Unit      string
+	Window    time.Duration
+	Reset     time.Time
+	// Source 제한이 적용되는 주체 (IP 주소, 계정 등 레지스트리가 알려준 값)
+	Source string
+	// Identity 조회에 사용한 인증 주체 (anonymous, 사용자명, 토큰 종류 등)
+	Identity  string
+	Throttled bool
+	Note      string
+}
+
+// HasLimit 제한 값이 확인되었는지 여부
+func (q Quota) HasLimit() bool {
+	return q.Limit > 0
+}
+
+// Provider 레지스트리 사용량 제한을 조회하는 공통 인터페이스
+type Provider interface {
+	// Name 결과 표시에 사용할 제공자 이름
+	Name() string
+	// Matches 정규화된 레지스트리 호스트를 이 제공자가 담당하는지 확인
+	Matches(registry string) bool
+	// Check 사용량 제한 정보를 조회
+	Check(ctx context.Context) ([]Quota, error)
+}
+
+// Result 제공자 하나의 조회 결과
+type Result struct {
+	Provider string
+	Quotas   []Quota
+	Err      error
+}
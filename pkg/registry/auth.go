@@ -0,0 +1,362 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultTokenLifetime expires_in이 없는 토큰 응답에 적용하는 기본 유효 기간 (distribution 스펙 기준)
+	defaultTokenLifetime = 60 * time.Second
+	// tokenExpiryMargin 만료 직전 토큰 재사용을 막기 위한 여유 시간
+	tokenExpiryMargin = 10 * time.Second
+)
+
+// Credentials 레지스트리 인증 정보
+type Credentials struct {
+	Username string
+	Password string
+	// IdentityToken docker login이 저장한 refresh 토큰 (OAuth2 refresh_token grant로 교환)
+	IdentityToken string
+	// RegistryToken 토큰 발급 없이 그대로 사용할 Bearer 토큰
+	RegistryToken string
+}
+
+// IsAnonymous 인증 정보가 하나도 설정되지 않았는지 확인
+func (c Credentials) IsAnonymous() bool {
+	return c.Username == "" && c.Password == "" && c.IdentityToken == "" && c.RegistryToken == ""
+}
+
+// CredentialFunc 레지스트리 호스트에 사용할 인증 정보를 반환하는 함수
+type CredentialFunc func(host string) Credentials
+
+// StaticCredentials 모든 레지스트리에 같은 인증 정보를 사용하는 CredentialFunc
+func StaticCredentials(creds Credentials) CredentialFunc {
+	return func(string) Credentials { return creds }
+}
+
+// cachedToken 만료 시각과 함께 캐시된 Bearer 토큰
+type cachedToken struct {
+	token   string
+	expires time.Time
+}
+
+// Transport 레지스트리의 Basic/Bearer challenge를 처리하고 토큰을 캐시하는 RoundTripper
+type Transport struct {
+	Base        http.RoundTripper
+	Credentials CredentialFunc
+	// Realm 설정되면 Bearer challenge의 realm 대신 사용할 토큰 엔드포인트
+	Realm string
+
+	mu         sync.Mutex
+	challenges map[string]Challenge
+	tokens     map[string]cachedToken
+}
+
+// NewTransport 기본 RoundTripper 위에 레지스트리 인증을 적용한 Transport를 생성
+func NewTransport(base http.RoundTripper, credentials CredentialFunc) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if credentials == nil {
+		credentials = StaticCredentials(Credentials{})
+	}
+	return &Transport{
+		Base:        base,
+		Credentials: credentials,
+		challenges:  make(map[string]Challenge),
+		tokens:      make(map[string]cachedToken),
+	}
+}
+
+// NewAuthClient 기존 HTTP 클라이언트의 설정을 유지하면서 레지스트리 인증을 적용한 클라이언트를 생성
+func NewAuthClient(httpClient *http.Client, credentials CredentialFunc) *http.Client {
+	authClient := *httpClient
+	authClient.Transport = NewTransport(httpClient.Transport, credentials)
+	return &authClient
+}
+
+// RoundTrip 알려진 challenge가 있으면 인증 헤더를 붙이고, 401 응답을 받으면 토큰을 발급받아 한 번 재시도
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	creds := t.Credentials(NormalizeRegistry(host))
+
+	t.mu.Lock()
+	challenge, known := t.challenges[host]
+	t.mu.Unlock()
+
+	// 이전에 받은 challenge가 있으면 첫 요청부터 인증 헤더를 붙임
+	authReq := req
+	if known {
+		var err error
+		// 저장된 challenge의 scope는 이전 요청의 저장소이므로 현재 요청 경로 기준으로 계산
+		authReq, err = t.authorize(req, challenge, creds, requestScope(req, challenge), false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := t.Base.RoundTrip(authReq)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// challenge 파싱 후 지원하는 scheme이 있으면 재시도
+	challenge, ok := selectChallenge(ResponseChallenges(resp))
+	if !ok || (challenge.Scheme == "basic" && creds.Username == "" && creds.Password == "") {
+		return resp, nil
+	}
+	if req.Body != nil && req.GetBody == nil {
+		// 본문을 다시 읽을 수 없으면 재시도 불가
+		return resp, nil
+	}
+
+	t.mu.Lock()
+	t.challenges[host] = challenge
+	t.mu.Unlock()
+
+	// cross-repository mount의 원본 저장소 pull 권한은 challenge에 없을 수 있으므로 요청 경로 기준 scope를 우선 사용
+	retryReq, err := t.authorize(req, challenge, creds, requestScope(req, challenge), known)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to rewind request body: %v", err)
+		}
+		retryReq.Body = body
+	}
+
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	return t.Base.RoundTrip(retryReq)
+}
+
+// selectChallenge 지원하는 challenge 중 Bearer를 우선 선택
+func selectChallenge(challenges []Challenge) (Challenge, bool) {
+	var basic *Challenge
+	for i := range challenges {
+		switch challenges[i].Scheme {
+		case "bearer":
+			return challenges[i], true
+		case "basic":
+			basic = &challenges[i]
+		}
+	}
+	if basic != nil {
+		return *basic, true
+	}
+	return Challenge{}, false
+}
+
+// authorize challenge에 맞는 Authorization 헤더를 설정한 요청 사본을 생성 (refresh가 true이면 캐시된 토큰을 무시)
+func (t *Transport) authorize(req *http.Request, challenge Challenge, creds Credentials, scope string, refresh bool) (*http.Request, error) {
+	authReq := req.Clone(req.Context())
+
+	switch challenge.Scheme {
+	case "basic":
+		if creds.Username != "" || creds.Password != "" {
+			authReq.SetBasicAuth(creds.Username, creds.Password)
+		}
+	case "bearer":
+		if creds.RegistryToken != "" {
+			authReq.Header.Set("Authorization", "Bearer "+creds.RegistryToken)
+			break
+		}
+		token, err := t.token(req.Context(), challenge, scope, creds, refresh)
+		if err != nil {
+			return nil, err
+		}
+		authReq.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return authReq, nil
+}
+
+// token 캐시된 토큰을 반환하거나 realm에서 새 토큰을 발급
+func (t *Transport) token(ctx context.Context, challenge Challenge, scope string, creds Credentials, refresh bool) (string, error) {
+	key := t.realm(challenge) + "|" + challenge.Service() + "|" + scope
+
+	t.mu.Lock()
+	cached, ok := t.tokens[key]
+	t.mu.Unlock()
+	if ok && !refresh && time.Now().Before(cached.expires) {
+		return cached.token, nil
+	}
+
+	fetched, err := t.fetchToken(ctx, challenge, scope, creds)
+	if err != nil {
+		return "", err
+	}
+
+	t.mu.Lock()
+	t.tokens[key] = fetched
+	t.mu.Unlock()
+	return fetched.token, nil
+}
+
+// fetchToken realm 엔드포인트에서 Bearer 토큰을 발급
+func (t *Transport) fetchToken(ctx context.Context, challenge Challenge, scope string, creds Credentials) (cachedToken, error) {
+	realm := t.realm(challenge)
+	if realm == "" {
+		return cachedToken{}, fmt.Errorf("bearer challenge has no realm")
+	}
+
+	var req *http.Request
+	var err error
+	if creds.IdentityToken != "" {
+		// refresh 토큰은 OAuth2 POST 방식으로 교환
+		form := url.Values{}
+		form.Set("grant_type", "refresh_token")
+		form.Set("refresh_token", creds.IdentityToken)
+		form.Set("service", challenge.Service())
+		form.Set("client_id", "zim")
+		for _, s := range strings.Fields(scope) {
+			form.Add("scope", s)
+		}
+		req, err = http.NewRequestWithContext(ctx, "POST", realm, strings.NewReader(form.Encode()))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	} else {
+		realmURL, parseErr := url.Parse(realm)
+		if parseErr != nil {
+			return cachedToken{}, fmt.Errorf("invalid realm %q: %v", realm, parseErr)
+		}
+		query := realmURL.Query()
+		if service := challenge.Service(); service != "" {
+			query.Set("service", service)
+		}
+		query.Del("scope")
+		for _, s := range strings.Fields(scope) {
+			query.Add("scope", s)
+		}
+		realmURL.RawQuery = query.Encode()
+		req, err = http.NewRequestWithContext(ctx, "GET", realmURL.String(), nil)
+		if err == nil && (creds.Username != "" || creds.Password != "") {
+			req.SetBasicAuth(creds.Username, creds.Password)
+		}
+	}
+	if err != nil {
+		return cachedToken{}, fmt.Errorf("failed to create token request: %v", err)
+	}
+
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil {
+		return cachedToken{}, fmt.Errorf("failed to get registry token: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return cachedToken{}, fmt.Errorf("token request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var tokenResp struct {
+		Token       string    `json:"token"`
+		AccessToken string    `json:"access_token"`
+		ExpiresIn   int       `json:"expires_in"`
+		IssuedAt    time.Time `json:"issued_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return cachedToken{}, fmt.Errorf("failed to decode token response: %v", err)
+	}
+
+	token := tokenResp.Token
+	if token == "" {
+		token = tokenResp.AccessToken
+	}
+	if token == "" {
+		return cachedToken{}, fmt.Errorf("token response contains no token")
+	}
+
+	lifetime := defaultTokenLifetime
+	if tokenResp.ExpiresIn > 0 {
+		lifetime = time.Duration(tokenResp.ExpiresIn) * time.Second
+	}
+	issuedAt := tokenResp.IssuedAt
+	if issuedAt.IsZero() || issuedAt.After(time.Now()) {
+		issuedAt = time.Now()
+	}
+
+	return cachedToken{token: token, expires: issuedAt.Add(lifetime - tokenExpiryMargin)}, nil
+}
+
+// realm 토큰을 발급받을 엔드포인트 (Realm이 설정되면 challenge의 realm보다 우선)
+func (t *Transport) realm(challenge Challenge) string {
+	if t.Realm != "" {
+		return t.Realm
+	}
+	return challenge.Realm()
+}
+
+// requestScope 요청 경로로 계산한 scope (경로로 알 수 없으면 challenge의 scope)
+func requestScope(req *http.Request, challenge Challenge) string {
+	if scope := scopeForRequest(req); scope != "" {
+		return scope
+	}
+	return challenge.Scope()
+}
+
+// scopeForRequest /v2/<name>/... 경로에서 저장소 scope를 계산 (쓰기 요청은 push 권한 포함)
+// cross-repository mount 요청은 원본 저장소(from)의 pull 권한도 필요하므로 scope를 공백으로 구분하여 추가
+func scopeForRequest(req *http.Request) string {
+	path := req.URL.Path
+	v2Index := strings.Index(path, "/v2/")
+	if v2Index == -1 {
+		return ""
+	}
+	path = path[v2Index+len("/v2/"):]
+
+	for _, marker := range []string{"/manifests/", "/blobs/", "/tags/", "/referrers/"} {
+		if i := strings.Index(path, marker); i > 0 {
+			action := "pull"
+			switch req.Method {
+			case "POST", "PUT", "PATCH":
+				action = "pull,push"
+			case "DELETE":
+				action = "delete"
+			}
+			scope := "repository:" + path[:i] + ":" + action
+			if from := req.URL.Query().Get("from"); req.Method == "POST" && from != "" && from != path[:i] {
+				scope += " repository:" + from + ":pull"
+			}
+			return scope
+		}
+	}
+	return ""
+}
+
+// Ping 레지스트리의 /v2/ 엔드포인트를 호출하여 API 지원 여부와 인증 challenge를 확인
+func Ping(ctx context.Context, httpClient *http.Client, registryURL string) ([]Challenge, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", strings.TrimRight(registryURL, "/")+"/v2/", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ping request: %v", err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ping registry: %v", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil, nil
+	case http.StatusUnauthorized:
+		return ResponseChallenges(resp), nil
+	default:
+		return nil, fmt.Errorf("registry ping failed with status %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,75 @@
+package registry
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestScopeForRequest(t *testing.T) {
+	tests := []struct {
+		method string
+		url    string
+		want   string
+	}{
+		{"GET", "https://registry.example.com/v2/library/nginx/manifests/1.25", "repository:library/nginx:pull"},
+		{"HEAD", "https://registry.example.com/v2/team/app/blobs/sha256:abc", "repository:team/app:pull"},
+		{"PUT", "https://registry.example.com/v2/mirror/docker.io/library/nginx/manifests/1.25", "repository:mirror/docker.io/library/nginx:pull,push"},
+		{"POST", "https://registry.example.com/v2/team/app/blobs/uploads/", "repository:team/app:pull,push"},
+		{"POST", "https://registry.example.com/v2/team/app/blobs/uploads/?from=team%2Fbase&mount=sha256%3Aabc",
+			"repository:team/app:pull,push repository:team/base:pull"},
+		{"POST", "https://registry.example.com/v2/team/app/blobs/uploads/?from=team%2Fapp&mount=sha256%3Aabc", "repository:team/app:pull,push"},
+		{"DELETE", "https://registry.example.com/v2/team/app/manifests/sha256:abc", "repository:team/app:delete"},
+		{"GET", "https://registry.example.com/v2/", ""},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest(tt.method, tt.url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := scopeForRequest(req); got != tt.want {
+			t.Errorf("scopeForRequest(%s %s) = %q, want %q", tt.method, tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestTransportMountScopeOnFirstChallenge(t *testing.T) {
+	var scopes []string
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scopes = r.URL.Query()["scope"]
+		w.Write([]byte(`{"token":"mount-token"}`))
+	}))
+	defer tokenServer.Close()
+
+	// challenge는 대상 저장소 scope만 안내
+	registryServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer mount-token" {
+			w.Header().Set("WWW-Authenticate",
+				`Bearer realm="`+tokenServer.URL+`",service="registry.example.com",scope="repository:team/app:pull,push"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer registryServer.Close()
+
+	// 호스트에 대한 첫 요청이 cross-repository mount
+	req, err := http.NewRequest("POST", registryServer.URL+"/v2/team/app/blobs/uploads/?from=team%2Fbase&mount=sha256%3Aabc", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := NewTransport(nil, nil).RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("RoundTrip() status = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+
+	want := []string{"repository:team/app:pull,push", "repository:team/base:pull"}
+	if !reflect.DeepEqual(scopes, want) {
+		t.Errorf("token request scopes = %q, want %q", scopes, want)
+	}
+}
@@ -0,0 +1,108 @@
+package registry
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Challenge WWW-Authenticate 헤더에서 파싱한 인증 요구 사항
+type Challenge struct {
+	Scheme     string
+	Parameters map[string]string
+}
+
+// Realm 토큰 발급 엔드포인트
+func (c Challenge) Realm() string {
+	return c.Parameters["realm"]
+}
+
+// Service 토큰 요청 시 전달할 service 파라미터
+func (c Challenge) Service() string {
+	return c.Parameters["service"]
+}
+
+// Scope 레지스트리가 요구한 scope 파라미터
+func (c Challenge) Scope() string {
+	return c.Parameters["scope"]
+}
+
+// ResponseChallenges 응답의 모든 WWW-Authenticate 헤더에서 challenge 목록을 파싱
+func ResponseChallenges(resp *http.Response) []Challenge {
+	var challenges []Challenge
+	for _, header := range resp.Header.Values("WWW-Authenticate") {
+		challenges = append(challenges, ParseChallenges(header)...)
+	}
+	return challenges
+}
+
+// ParseChallenges `Bearer realm="...",service="...",scope="..."` 형식의 헤더 값을 파싱
+func ParseChallenges(header string) []Challenge {
+	var challenges []Challenge
+	var current *Challenge
+
+	s := strings.TrimSpace(header)
+	for s != "" {
+		// 토큰 (scheme 또는 파라미터 이름) 읽기
+		token, rest := readToken(s)
+		rest = strings.TrimLeft(rest, " \t")
+
+		if token != "" && strings.HasPrefix(rest, "=") && current != nil {
+			// 파라미터: name=value 또는 name="value"
+			value, remaining := readValue(strings.TrimLeft(rest[1:], " \t"))
+			current.Parameters[strings.ToLower(token)] = value
+			s = remaining
+		} else if token != "" {
+			// 새 challenge 시작
+			challenges = append(challenges, Challenge{
+				Scheme:     strings.ToLower(token),
+				Parameters: make(map[string]string),
+			})
+			current = &challenges[len(challenges)-1]
+			s = rest
+		} else {
+			s = rest[1:]
+		}
+		s = strings.TrimLeft(s, " \t,")
+	}
+
+	return challenges
+}
+
+// readToken 구분자(공백, '=', ',') 전까지의 토큰을 읽음
+func readToken(s string) (string, string) {
+	i := strings.IndexAny(s, " \t=,")
+	if i == -1 {
+		return s, ""
+	}
+	if i == 0 {
+		return "", s
+	}
+	return s[:i], s[i:]
+}
+
+// readValue 따옴표로 감싼 값(이스케이프 포함) 또는 따옴표 없는 값을 읽음
+func readValue(s string) (string, string) {
+	if !strings.HasPrefix(s, `"`) {
+		i := strings.IndexAny(s, " \t,")
+		if i == -1 {
+			return s, ""
+		}
+		return s[:i], s[i:]
+	}
+
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				b.WriteByte(s[i])
+			}
+		case '"':
+			return b.String(), s[i+1:]
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String(), ""
+}
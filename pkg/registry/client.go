@@ -0,0 +1,300 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/httpclient"
+)
+
+// maxManifestSize 매니페스트 본문의 최대 크기 (distribution 스펙 권장값)
+const maxManifestSize = 4 * 1024 * 1024
+
+// Client OCI distribution 레지스트리 클라이언트 (태그, 매니페스트, blob 조회)
+type Client struct {
+	HTTPClient *http.Client
+	// Endpoints 레지스트리 호스트별 API 기본 URL (예: docker.io의 --docker-registry-url, 없는 호스트는 BaseURL 규칙)
+	Endpoints map[string]string
+}
+
+// NewClient 레지스트리 인증이 적용된 클라이언트를 생성 (httpClient가 nil이면 기본 클라이언트 사용)
+func NewClient(httpClient *http.Client, credentials CredentialFunc) *Client {
+	if httpClient == nil {
+		httpClient = httpclient.Default()
+	}
+	return &Client{HTTPClient: NewAuthClient(httpClient, credentials)}
+}
+
+// endpoint 레지스트리 호스트의 API 기본 URL (Endpoints에 설정된 값 우선)
+func (c *Client) endpoint(host string) string {
+	if url, ok := c.Endpoints[NormalizeRegistry(host)]; ok && url != "" {
+		return strings.TrimSuffix(url, "/")
+	}
+	return BaseURL(host)
+}
+
+// ListTags 저장소의 모든 태그를 조회 (Link 헤더 페이지네이션 지원)
+func (c *Client) ListTags(ctx context.Context, ref Reference) ([]string, error) {
+	next := fmt.Sprintf("%s/v2/%s/tags/list?n=1000", c.endpoint(ref.Registry), ref.Repository)
+
+	var tags []string
+	for next != "" {
+		req, err := http.NewRequestWithContext(ctx, "GET", next, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create tags request: %v", err)
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tags for %s: %v", ref.Name(), err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tags response: %v", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, responseError("list tags for "+ref.Name(), resp.StatusCode, body)
+		}
+
+		var page struct {
+			Tags []string `json:"tags"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to decode tags response: %v", err)
+		}
+		tags = append(tags, page.Tags...)
+
+		next, err = nextPageURL(req.URL, resp.Header.Get("Link"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tags, nil
+}
+
+// nextPageURL `<url>; rel="next"` 형식의 Link 헤더에서 다음 페이지 URL을 계산
+func nextPageURL(current *url.URL, link string) (string, error) {
+	if link == "" {
+		return "", nil
+	}
+	start := strings.Index(link, "<")
+	end := strings.Index(link, ">")
+	if start == -1 || end < start || !strings.Contains(link[end:], `rel="next"`) {
+		return "", nil
+	}
+	nextURL, err := current.Parse(link[start+1 : end])
+	if err != nil {
+		return "", fmt.Errorf("invalid Link header %q: %v", link, err)
+	}
+	return nextURL.String(), nil
+}
+
+// HeadManifest 매니페스트를 내려받지 않고 digest, 미디어 타입, 크기를 조회
+func (c *Client) HeadManifest(ctx context.Context, ref Reference) (Descriptor, error) {
+	resp, err := c.manifestRequest(ctx, "HEAD", ref)
+	if err != nil {
+		return Descriptor{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Descriptor{}, responseError("head manifest "+ref.String(), resp.StatusCode, nil)
+	}
+
+	desc := Descriptor{
+		MediaType: resp.Header.Get("Content-Type"),
+		Digest:    resp.Header.Get("Docker-Content-Digest"),
+		Size:      resp.ContentLength,
+	}
+	// 일부 레지스트리는 HEAD에 digest를 주지 않으므로 GET으로 계산
+	if desc.Digest == "" {
+		manifest, err := c.GetManifest(ctx, ref)
+		if err != nil {
+			return Descriptor{}, err
+		}
+		desc = Descriptor{MediaType: manifest.MediaType, Digest: manifest.Digest, Size: int64(len(manifest.Raw))}
+	}
+	return desc, nil
+}
+
+// GetManifest 태그 또는 digest로 매니페스트(또는 인덱스)를 조회하고 digest를 검증
+func (c *Client) GetManifest(ctx context.Context, ref Reference) (*Manifest, error) {
+	resp, err := c.manifestRequest(ctx, "GET", ref)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError("get manifest "+ref.String(), resp.StatusCode, body)
+	}
+	if len(body) > maxManifestSize {
+		return nil, fmt.Errorf("manifest %s exceeds %d bytes", ref, maxManifestSize)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest %s: %v", ref, err)
+	}
+	if manifest.MediaType == "" {
+		manifest.MediaType = strings.TrimSpace(strings.SplitN(resp.Header.Get("Content-Type"), ";", 2)[0])
+	}
+	manifest.Raw = body
+	manifest.Digest = Digest(body)
+
+	// digest로 조회한 경우 본문이 일치하는지 확인
+	if ref.Digest != "" && ref.Digest != manifest.Digest {
+		return nil, fmt.Errorf("manifest digest mismatch for %s: got %s", ref, manifest.Digest)
+	}
+	return &manifest, nil
+}
+
+// manifestRequest 모든 매니페스트 미디어 타입을 허용하는 요청을 전송
+func (c *Client) manifestRequest(ctx context.Context, method string, ref Reference) (*http.Response, error) {
+	manifestURL := fmt.Sprintf("%s/v2/%s/manifests/%s", c.endpoint(ref.Registry), ref.Repository, ref.Identifier())
+	req, err := http.NewRequestWithContext(ctx, method, manifestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create manifest request: %v", err)
+	}
+	req.Header.Set("Accept", ManifestAccept)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request manifest %s: %v", ref, err)
+	}
+	return resp, nil
+}
+
+// GetBlob 저장소의 blob을 스트림으로 조회 (호출자가 Close 해야 함)
+func (c *Client) GetBlob(ctx context.Context, ref Reference, digest string) (io.ReadCloser, int64, error) {
+	blobURL := fmt.Sprintf("%s/v2/%s/blobs/%s", c.endpoint(ref.Registry), ref.Repository, digest)
+	req, err := http.NewRequestWithContext(ctx, "GET", blobURL, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create blob request: %v", err)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get blob %s: %v", digest, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+		resp.Body.Close()
+		return nil, 0, responseError("get blob "+digest, resp.StatusCode, body)
+	}
+	return resp.Body, resp.ContentLength, nil
+}
+
+// GetConfig 이미지 매니페스트의 config blob을 조회
+func (c *Client) GetConfig(ctx context.Context, ref Reference, manifest *Manifest) (*ImageConfig, error) {
+	if manifest.IsIndex() {
+		return nil, fmt.Errorf("%s is a manifest index; select a platform manifest first", ref)
+	}
+
+	body, _, err := c.GetBlob(ctx, ref, manifest.Config.Digest)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, maxManifestSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config blob: %v", err)
+	}
+	if Digest(data) != manifest.Config.Digest {
+		return nil, fmt.Errorf("config digest mismatch for %s", ref)
+	}
+
+	var config ImageConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to decode image config: %v", err)
+	}
+	return &config, nil
+}
+
+// ImageSizes 이미지의 플랫폼별 압축 크기를 계산 (단일 매니페스트면 config의 플랫폼 하나만 반환)
+func (c *Client) ImageSizes(ctx context.Context, ref Reference) ([]ImageSize, error) {
+	manifest, err := c.GetManifest(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if !manifest.IsIndex() {
+		platform := ""
+		if config, err := c.GetConfig(ctx, ref, manifest); err == nil {
+			platform = config.Platform().String()
+		}
+		return []ImageSize{{
+			Platform: platform,
+			Digest:   manifest.Digest,
+			Size:     manifest.CompressedSize(),
+			Layers:   len(manifest.Layers),
+		}}, nil
+	}
+
+	var sizes []ImageSize
+	for _, desc := range manifest.Manifests {
+		if isAttestation(desc) {
+			continue
+		}
+		child, err := c.GetManifest(ctx, ref.WithDigest(desc.Digest))
+		if err != nil {
+			return nil, err
+		}
+		platform := ""
+		if desc.Platform != nil {
+			platform = desc.Platform.String()
+		}
+		sizes = append(sizes, ImageSize{
+			Platform: platform,
+			Digest:   desc.Digest,
+			Size:     child.CompressedSize(),
+			Layers:   len(child.Layers),
+		})
+	}
+	return sizes, nil
+}
+
+// PlatformManifest 인덱스에서 지정된 플랫폼의 매니페스트를 선택하여 조회 (단일 매니페스트는 그대로 반환)
+func (c *Client) PlatformManifest(ctx context.Context, ref Reference, manifest *Manifest, platform Platform) (*Manifest, error) {
+	if !manifest.IsIndex() {
+		return manifest, nil
+	}
+	for _, desc := range manifest.Manifests {
+		if desc.Platform == nil || isAttestation(desc) {
+			continue
+		}
+		if desc.Platform.OS == platform.OS && desc.Platform.Architecture == platform.Architecture &&
+			(platform.Variant == "" || desc.Platform.Variant == platform.Variant) {
+			return c.GetManifest(ctx, ref.WithDigest(desc.Digest))
+		}
+	}
+	return nil, fmt.Errorf("%s has no manifest for platform %s", ref, platform)
+}
+
+// Digest 본문의 sha256 digest를 "sha256:<hex>" 형식으로 계산
+func Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// responseError 상태 코드와 레지스트리 오류 본문으로 오류를 생성
+func responseError(action string, statusCode int, body []byte) error {
+	if errs := ParseErrorResponse(body); len(errs) > 0 {
+		return &StatusError{StatusCode: statusCode, Message: fmt.Sprintf("%s failed with status %d: %s", action, statusCode, FormatErrors(errs))}
+	}
+	return &StatusError{StatusCode: statusCode, Message: fmt.Sprintf("%s failed with status %d", action, statusCode)}
+}
@@ -0,0 +1,77 @@
+package registry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// dockerConfigAuth docker config.json의 auths 항목
+type dockerConfigAuth struct {
+	Auth          string `json:"auth"`
+	Username      string `json:"username"`
+	Password      string `json:"password"`
+	IdentityToken string `json:"identitytoken"`
+	RegistryToken string `json:"registrytoken"`
+}
+
+// DefaultDockerConfigPath DOCKER_CONFIG 또는 $HOME/.docker 아래의 config.json 경로
+func DefaultDockerConfigPath() string {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json")
+	}
+	return filepath.Join(os.Getenv("HOME"), ".docker", "config.json")
+}
+
+// DockerConfigCredentials docker config.json (또는 dockerconfigjson Secret 형식)에서 레지스트리별 인증 정보를 읽음 (파일이 없으면 익명)
+func DockerConfigCredentials(path string) (CredentialFunc, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return StaticCredentials(Credentials{}), nil
+		}
+		return nil, fmt.Errorf("failed to read docker config: %v", err)
+	}
+
+	creds, err := ParseDockerConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse docker config %s: %v", path, err)
+	}
+	return func(host string) Credentials {
+		return creds[NormalizeRegistry(host)]
+	}, nil
+}
+
+// ParseDockerConfig config.json의 auths를 정규화된 레지스트리 호스트별 인증 정보로 변환
+func ParseDockerConfig(data []byte) (map[string]Credentials, error) {
+	var config struct {
+		Auths map[string]dockerConfigAuth `json:"auths"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	creds := make(map[string]Credentials)
+	for host, auth := range config.Auths {
+		c := Credentials{
+			Username:      auth.Username,
+			Password:      auth.Password,
+			IdentityToken: auth.IdentityToken,
+			RegistryToken: auth.RegistryToken,
+		}
+		if auth.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("invalid auth for %s: %v", host, err)
+			}
+			if username, password, ok := strings.Cut(string(decoded), ":"); ok {
+				c.Username, c.Password = username, password
+			}
+		}
+		creds[NormalizeRegistry(host)] = c
+	}
+	return creds, nil
+}
@@ -0,0 +1,64 @@
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Error OCI distribution 스펙의 오류 응답 항목
+type Error struct {
+	Code    string          `json:"code"`
+	Message string          `json:"message"`
+	Detail  json.RawMessage `json:"detail,omitempty"`
+}
+
+// ErrorResponse 레지스트리가 반환하는 {"errors": [...]} 형식의 오류 응답
+type ErrorResponse struct {
+	Errors []Error `json:"errors"`
+}
+
+// ParseErrorResponse 응답 본문에서 레지스트리 오류 목록을 파싱 (형식이 다르면 nil 반환)
+func ParseErrorResponse(body []byte) []Error {
+	var errResp ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err != nil {
+		return nil
+	}
+	return errResp.Errors
+}
+
+// String "CODE: message" 형식의 문자열을 반환
+func (e Error) String() string {
+	if e.Message == "" {
+		return e.Code
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// FormatErrors 오류 목록을 한 줄 문자열로 변환
+func FormatErrors(errs []Error) string {
+	var parts []string
+	for _, e := range errs {
+		parts = append(parts, e.String())
+	}
+	return strings.Join(parts, "; ")
+}
+
+// StatusError 레지스트리 요청이 성공하지 못한 경우의 오류 (상태 코드 확인용)
+type StatusError struct {
+	StatusCode int
+	Message    string
+}
+
+// Error 오류 메시지
+func (e *StatusError) Error() string {
+	return e.Message
+}
+
+// IsNotFound 오류가 404 응답으로 인한 것인지 확인
+func IsNotFound(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound
+}
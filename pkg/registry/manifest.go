@@ -0,0 +1,111 @@
+package registry
+
+import (
+	"strings"
+	"time"
+)
+
+// Platform 이미지가 실행되는 OS와 아키텍처
+type Platform struct {
+	Architecture string   `json:"architecture"`
+	OS           string   `json:"os"`
+	Variant      string   `json:"variant,omitempty"`
+	OSVersion    string   `json:"os.version,omitempty"`
+	OSFeatures   []string `json:"os.features,omitempty"`
+}
+
+// String "os/arch/variant" 형식의 플랫폼 문자열
+func (p Platform) String() string {
+	s := p.OS + "/" + p.Architecture
+	if p.Variant != "" {
+		s += "/" + p.Variant
+	}
+	return s
+}
+
+// Descriptor 매니페스트, config, 레이어를 가리키는 content descriptor
+type Descriptor struct {
+	MediaType    string            `json:"mediaType"`
+	Digest       string            `json:"digest"`
+	Size         int64             `json:"size"`
+	ArtifactType string            `json:"artifactType,omitempty"`
+	Platform     *Platform         `json:"platform,omitempty"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+}
+
+// Manifest 이미지 매니페스트와 매니페스트 인덱스(리스트)를 함께 표현하는 구조체
+type Manifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType,omitempty"`
+	ArtifactType  string            `json:"artifactType,omitempty"`
+	Config        Descriptor        `json:"config"`
+	Layers        []Descriptor      `json:"layers,omitempty"`
+	Manifests     []Descriptor      `json:"manifests,omitempty"`
+	Subject       *Descriptor       `json:"subject,omitempty"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+
+	// Digest 매니페스트 본문의 digest
+	Digest string `json:"-"`
+	// Raw 레지스트리가 반환한 원본 본문 (digest 계산 및 복사에 사용)
+	Raw []byte `json:"-"`
+}
+
+// IsIndex 멀티 플랫폼 인덱스인지 확인
+func (m *Manifest) IsIndex() bool {
+	return IsIndex(m.MediaType) || (m.MediaType == "" && len(m.Manifests) > 0)
+}
+
+// CompressedSize config와 모든 레이어의 압축된 크기 합계 (인덱스에는 적용되지 않음)
+func (m *Manifest) CompressedSize() int64 {
+	size := m.Config.Size
+	for _, layer := range m.Layers {
+		size += layer.Size
+	}
+	return size
+}
+
+// ImageConfig 이미지 config blob 중 메타데이터 관련 필드
+type ImageConfig struct {
+	Created      *time.Time `json:"created,omitempty"`
+	Author       string     `json:"author,omitempty"`
+	Architecture string     `json:"architecture"`
+	OS           string     `json:"os"`
+	Variant      string     `json:"variant,omitempty"`
+	Config       struct {
+		Labels map[string]string `json:"Labels,omitempty"`
+	} `json:"config"`
+}
+
+// Platform config에 기록된 플랫폼 정보
+func (c *ImageConfig) Platform() Platform {
+	return Platform{Architecture: c.Architecture, OS: c.OS, Variant: c.Variant}
+}
+
+// ImageSize 플랫폼별 이미지 크기
+type ImageSize struct {
+	Platform string
+	Digest   string
+	// Size config와 레이어의 압축된 크기 합계 (레지스트리 전송량 기준)
+	Size   int64
+	Layers int
+}
+
+// IndexPlatforms 인덱스가 제공하는 플랫폼 목록 (attestation 매니페스트 제외)
+func IndexPlatforms(manifest *Manifest) []Platform {
+	var platforms []Platform
+	for _, desc := range manifest.Manifests {
+		if desc.Platform == nil || isAttestation(desc) {
+			continue
+		}
+		platforms = append(platforms, *desc.Platform)
+	}
+	return platforms
+}
+
+// isAttestation 인덱스 항목이 buildkit attestation 매니페스트인지 확인 (플랫폼 unknown/unknown)
+func isAttestation(desc Descriptor) bool {
+	if desc.Annotations["vnd.docker.reference.type"] == "attestation-manifest" {
+		return true
+	}
+	return desc.Platform != nil && strings.EqualFold(desc.Platform.OS, "unknown")
+}
@@ -0,0 +1,39 @@
+package registry
+
+import "strings"
+
+// 매니페스트 및 config 미디어 타입
+const (
+	MediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	MediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	MediaTypeDockerConfig       = "application/vnd.docker.container.image.v1+json"
+	MediaTypeOCIManifest        = "application/vnd.oci.image.manifest.v1+json"
+	MediaTypeOCIIndex           = "application/vnd.oci.image.index.v1+json"
+	MediaTypeOCIConfig          = "application/vnd.oci.image.config.v1+json"
+)
+
+// ManifestAccept 매니페스트 조회 시 Accept 헤더로 보내는 미디어 타입 목록
+var ManifestAccept = strings.Join([]string{
+	MediaTypeOCIIndex,
+	MediaTypeOCIManifest,
+	MediaTypeDockerManifestList,
+	MediaTypeDockerManifest,
+}, ", ")
+
+// IsIndex 멀티 플랫폼 인덱스(매니페스트 리스트) 미디어 타입인지 확인
+func IsIndex(mediaType string) bool {
+	return mediaType == MediaTypeOCIIndex || mediaType == MediaTypeDockerManifestList
+}
+
+// BaseURL 레지스트리 호스트의 기본 API URL을 반환 (docker.io는 registry-1.docker.io, localhost는 HTTP 사용)
+// 설정된 엔드포인트가 있으면 Client.Endpoints로 덮어씀
+func BaseURL(host string) string {
+	host = NormalizeRegistry(host)
+	if host == DockerHubRegistry {
+		return "https://registry-1.docker.io"
+	}
+	if host == "localhost" || strings.HasPrefix(host, "localhost:") || strings.HasPrefix(host, "127.0.0.1") {
+		return "http://" + host
+	}
+	return "https://" + host
+}
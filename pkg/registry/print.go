@@ -0,0 +1,34 @@
+package registry
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/units"
+)
+
+// PrintImageInfo 이미지의 매니페스트 정보와 플랫폼별 크기를 출력
+func PrintImageInfo(ref Reference, manifest *Manifest, sizes []ImageSize, tags []string) {
+	fmt.Printf("\nImage: %s\n", ref)
+	fmt.Printf("================================\n")
+	fmt.Printf("Digest: %s\n", manifest.Digest)
+	fmt.Printf("Media Type: %s\n", manifest.MediaType)
+	if tags != nil {
+		fmt.Printf("Tags: %d\n", len(tags))
+	}
+	fmt.Printf("================================\n")
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintln(w, "Platform\tDigest\tLayers\tCompressed Size")
+	fmt.Fprintln(w, "-----------------------------------------------------------------------")
+	for _, size := range sizes {
+		platform := size.Platform
+		if platform == "" {
+			platform = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", platform, size.Digest, size.Layers, units.FormatBytes(size.Size))
+	}
+	w.Flush()
+	fmt.Println()
+}
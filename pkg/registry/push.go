@@ -0,0 +1,127 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// BlobExists 저장소에 blob이 이미 있는지 HEAD 요청으로 확인
+func (c *Client) BlobExists(ctx context.Context, ref Reference, digest string) (bool, error) {
+	blobURL := fmt.Sprintf("%s/v2/%s/blobs/%s", c.endpoint(ref.Registry), ref.Repository, digest)
+	req, err := http.NewRequestWithContext(ctx, "HEAD", blobURL, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create blob request: %v", err)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to check blob %s: %v", digest, err)
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	}
+	return false, responseError("head blob "+digest, resp.StatusCode, nil)
+}
+
+// MountBlob 같은 레지스트리의 다른 저장소에 있는 blob을 전송 없이 연결 (cross-repository mount)
+// 레지스트리가 mount를 거부하면 false를 반환하므로 호출자가 업로드해야 함
+func (c *Client) MountBlob(ctx context.Context, ref Reference, digest, fromRepository string) (bool, error) {
+	query := url.Values{"mount": {digest}, "from": {fromRepository}}
+	mountURL := fmt.Sprintf("%s/v2/%s/blobs/uploads/?%s", c.endpoint(ref.Registry), ref.Repository, query.Encode())
+	req, err := http.NewRequestWithContext(ctx, "POST", mountURL, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create mount request: %v", err)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to mount blob %s: %v", digest, err)
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		return true, nil
+	case http.StatusAccepted:
+		// mount 대신 업로드 세션이 시작됨 (세션은 레지스트리에서 만료 처리)
+		return false, nil
+	}
+	return false, responseError("mount blob "+digest, resp.StatusCode, body)
+}
+
+// PutBlob blob을 한 번의 PUT으로 업로드 (POST로 업로드 세션을 연 뒤 digest와 함께 본문 전송)
+func (c *Client) PutBlob(ctx context.Context, ref Reference, digest string, size int64, content io.Reader) error {
+	startURL := fmt.Sprintf("%s/v2/%s/blobs/uploads/", c.endpoint(ref.Registry), ref.Repository)
+	req, err := http.NewRequestWithContext(ctx, "POST", startURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create upload request: %v", err)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to start upload of %s: %v", digest, err)
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return responseError("start upload of "+digest, resp.StatusCode, body)
+	}
+
+	// Location은 상대 경로일 수 있으므로 요청 URL 기준으로 해석
+	location, err := resp.Request.URL.Parse(resp.Header.Get("Location"))
+	if err != nil || resp.Header.Get("Location") == "" {
+		return fmt.Errorf("registry returned invalid upload location %q", resp.Header.Get("Location"))
+	}
+	query := location.Query()
+	query.Set("digest", digest)
+	location.RawQuery = query.Encode()
+
+	req, err = http.NewRequestWithContext(ctx, "PUT", location.String(), content)
+	if err != nil {
+		return fmt.Errorf("failed to create upload request: %v", err)
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err = c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload blob %s: %v", digest, err)
+	}
+	body, _ = io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return responseError("upload blob "+digest, resp.StatusCode, body)
+	}
+	return nil
+}
+
+// PutManifest 원본 본문 그대로 매니페스트를 업로드 (태그 또는 digest로 지정, digest가 유지됨)
+func (c *Client) PutManifest(ctx context.Context, ref Reference, mediaType string, raw []byte) error {
+	manifestURL := fmt.Sprintf("%s/v2/%s/manifests/%s", c.endpoint(ref.Registry), ref.Repository, ref.Identifier())
+	req, err := http.NewRequestWithContext(ctx, "PUT", manifestURL, bytes.NewReader(raw))
+	if err != nil {
+		return fmt.Errorf("failed to create manifest request: %v", err)
+	}
+	req.Header.Set("Content-Type", mediaType)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to put manifest %s: %v", ref, err)
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return responseError("put manifest "+ref.String(), resp.StatusCode, body)
+	}
+	return nil
+}
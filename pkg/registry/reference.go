@@ -0,0 +1,128 @@
+package registry
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DockerHubRegistry Docker Hub 레지스트리의 정규화된 호스트 이름
+const DockerHubRegistry = "docker.io"
+
+// dockerHubAliases Docker Hub를 가리키는 것으로 취급하는 호스트 이름 목록
+var dockerHubAliases = map[string]bool{
+	"docker.io":               true,
+	"index.docker.io":         true,
+	"registry-1.docker.io":    true,
+	"registry.hub.docker.com": true,
+}
+
+// Reference 이미지 참조를 레지스트리, 저장소, 태그, 다이제스트로 분해한 결과
+type Reference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// ParseReference 이미지 참조 문자열을 분해 (레지스트리가 없으면 docker.io로 간주)
+func ParseReference(image string) (Reference, error) {
+	image = strings.TrimSpace(image)
+	if image == "" {
+		return Reference{}, fmt.Errorf("empty image reference")
+	}
+
+	original := image
+	var ref Reference
+
+	// @sha256:... 다이제스트 분리
+	if atIndex := strings.Index(image, "@"); atIndex != -1 {
+		ref.Digest = image[atIndex+1:]
+		image = image[:atIndex]
+	}
+
+	// 마지막 '/' 이후의 ':'만 태그로 취급 (포트가 있는 레지스트리 대응)
+	if colonIndex := strings.LastIndex(image, ":"); colonIndex > strings.LastIndex(image, "/") {
+		ref.Tag = image[colonIndex+1:]
+		image = image[:colonIndex]
+	}
+
+	// 첫 번째 구성 요소가 호스트처럼 보이면 레지스트리로 사용
+	if slashIndex := strings.Index(image, "/"); slashIndex != -1 {
+		host := image[:slashIndex]
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			ref.Registry = NormalizeRegistry(host)
+			image = image[slashIndex+1:]
+		}
+	}
+	if ref.Registry == "" {
+		ref.Registry = DockerHubRegistry
+	}
+
+	// Docker Hub 공식 이미지는 library/ 네임스페이스 사용
+	if ref.Registry == DockerHubRegistry && !strings.Contains(image, "/") {
+		image = "library/" + image
+	}
+	if image == "" {
+		return Reference{}, fmt.Errorf("invalid image reference %q: missing repository", original)
+	}
+	ref.Repository = image
+
+	return ref, nil
+}
+
+// NormalizeRegistry 레지스트리 호스트 이름을 비교 가능한 형태로 정규화
+func NormalizeRegistry(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	host = strings.TrimPrefix(host, "https://")
+	host = strings.TrimPrefix(host, "http://")
+	if slashIndex := strings.Index(host, "/"); slashIndex != -1 {
+		host = host[:slashIndex]
+	}
+	if dockerHubAliases[host] {
+		return DockerHubRegistry
+	}
+	return host
+}
+
+// IsDockerHub 호스트 이름이 Docker Hub를 가리키는지 확인
+func IsDockerHub(host string) bool {
+	return NormalizeRegistry(host) == DockerHubRegistry
+}
+
+// Name 태그와 다이제스트를 제외한 레지스트리/저장소 이름을 반환
+func (r Reference) Name() string {
+	return r.Registry + "/" + r.Repository
+}
+
+// Identifier 매니페스트 조회에 사용할 다이제스트 또는 태그를 반환 (기본값 latest)
+func (r Reference) Identifier() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+	if r.Tag != "" {
+		return r.Tag
+	}
+	return "latest"
+}
+
+// String 정규화된 전체 이미지 참조를 반환
+func (r Reference) String() string {
+	s := r.Name()
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// WithDigest 같은 저장소에서 digest로 지정한 참조를 반환 (태그 제거)
+func (r Reference) WithDigest(digest string) Reference {
+	return Reference{Registry: r.Registry, Repository: r.Repository, Digest: digest}
+}
+
+// WithTag 같은 저장소에서 태그로 지정한 참조를 반환 (digest 제거)
+func (r Reference) WithTag(tag string) Reference {
+	return Reference{Registry: r.Registry, Repository: r.Repository, Tag: tag}
+}
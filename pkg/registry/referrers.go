@@ -0,0 +1,60 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Referrers digest를 subject로 가지는 아티팩트(서명, attestation, SBOM 등) 목록을 조회
+// referrers API를 지원하지 않는 레지스트리는 "sha256-<hex>" 태그 스키마로 대체 조회
+func (c *Client) Referrers(ctx context.Context, ref Reference, digest string) ([]Descriptor, error) {
+	referrersURL := fmt.Sprintf("%s/v2/%s/referrers/%s", c.endpoint(ref.Registry), ref.Repository, digest)
+	req, err := http.NewRequestWithContext(ctx, "GET", referrersURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create referrers request: %v", err)
+	}
+	req.Header.Set("Accept", MediaTypeOCIIndex)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request referrers of %s: %v", digest, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read referrers: %v", err)
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var index Manifest
+		if err := json.Unmarshal(body, &index); err != nil {
+			return nil, fmt.Errorf("failed to decode referrers of %s: %v", digest, err)
+		}
+		return index.Manifests, nil
+	case http.StatusNotFound, http.StatusMethodNotAllowed:
+		return c.referrersTag(ctx, ref, digest)
+	}
+	return nil, responseError("get referrers of "+digest, resp.StatusCode, body)
+}
+
+// referrersTag referrers 태그 스키마("sha256-<hex>" 태그의 인덱스)로 아티팩트 목록을 조회
+func (c *Client) referrersTag(ctx context.Context, ref Reference, digest string) ([]Descriptor, error) {
+	index, err := c.GetManifest(ctx, ref.WithTag(DigestTag(digest, "")))
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return index.Manifests, nil
+}
+
+// DigestTag digest를 태그로 쓸 수 있는 "sha256-<hex><suffix>" 형식으로 변환 (예: cosign의 ".sig")
+func DigestTag(digest, suffix string) string {
+	return strings.Replace(digest, ":", "-", 1) + suffix
+}
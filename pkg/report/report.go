@@ -0,0 +1,72 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/output"
+	"github.com/suslmk-lee/zim-image-management/pkg/ratelimit"
+)
+
+// SchemaVersion JSON/YAML 출력 문서의 스키마 버전
+// 필드 추가는 같은 버전에서 허용하고, 필드 제거나 의미 변경 시에만 올림
+const SchemaVersion = "zim/v1"
+
+// 표 형식(csv, markdown)으로 출력할 수 있는 표
+const (
+	TableImages     = "images"
+	TableRateLimits = "rate-limits"
+)
+
+// Tables 플래그 도움말에 표시할 표 이름 목록
+const Tables = "images, rate-limits"
+
+// ParseTable 표 이름을 확인 (빈 문자열은 형식별 기본값)
+func ParseTable(value string) (string, error) {
+	switch table := strings.ToLower(strings.TrimSpace(value)); table {
+	case "", TableImages, TableRateLimits:
+		return table, nil
+	case "ratelimits":
+		return TableRateLimits, nil
+	}
+	return "", fmt.Errorf("unknown table %q: expected one of %s", value, Tables)
+}
+
+// Report 기본 명령(zim)의 풀 통계와 rate limit 결과 문서 (README의 "출력 형식" 참고)
+type Report struct {
+	SchemaVersion  string                     `json:"schemaVersion"`
+	GeneratedAt    time.Time                  `json:"generatedAt"`
+	PullStatistics *kubernetes.PullStatistics `json:"pullStatistics"`
+	RateLimits     []ratelimit.Record         `json:"rateLimits"`
+}
+
+// New 수집한 풀 통계와 rate limit 조회 결과로 문서를 생성
+func New(stats *kubernetes.PullStatistics, results []ratelimit.Result) *Report {
+	return &Report{
+		SchemaVersion:  SchemaVersion,
+		GeneratedAt:    time.Now().UTC(),
+		PullStatistics: stats,
+		RateLimits:     ratelimit.Records(results),
+	}
+}
+
+// Write 문서를 table 외의 형식(json, yaml, csv, markdown)으로 출력
+// csv와 markdown은 table로 지정한 표만 출력하고, table이 비어 있으면 csv는 이미지별 풀 통계 표를,
+// markdown은 풀 통계 표와 rate limit 표를 차례로 출력 (json, yaml은 table과 무관하게 전체 문서)
+func (r *Report) Write(w io.Writer, format output.Format, table string) error {
+	if table == "" && format == output.FormatCSV {
+		table = TableImages
+	}
+
+	var tables []output.Table
+	if r.PullStatistics != nil && (table == "" || table == TableImages) {
+		tables = append(tables, r.PullStatistics.Table())
+	}
+	if table == "" || table == TableRateLimits {
+		tables = append(tables, ratelimit.RecordsTable(r.RateLimits))
+	}
+	return output.Render(w, format, r, tables)
+}
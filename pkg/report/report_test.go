@@ -0,0 +1,94 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/output"
+	"github.com/suslmk-lee/zim-image-management/pkg/ratelimit"
+)
+
+func testReport() *Report {
+	return &Report{
+		SchemaVersion: SchemaVersion,
+		PullStatistics: &kubernetes.PullStatistics{
+			PeriodHours: 24,
+			Images: []kubernetes.ImagePullStat{
+				{Image: "docker.io/library/nginx", Registry: "docker.io", Pulls: 3, InUse: true},
+				{Image: "quay.io/calico/cni", Registry: "quay.io", Pulls: 1},
+			},
+		},
+		RateLimits: []ratelimit.Record{
+			{Provider: "docker.io", Registry: "docker.io", Resource: "pulls", Unit: "requests", Limit: 100, Remaining: 42},
+		},
+	}
+}
+
+func TestWriteCSVSingleTable(t *testing.T) {
+	tests := []struct {
+		table      string
+		wantHeader string
+		wantRows   int
+	}{
+		{"", "image", 2},
+		{TableImages, "image", 2},
+		{TableRateLimits, "provider", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.table, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := testReport().Write(&buf, output.FormatCSV, tt.table); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			// 한 스트림은 헤더 하나와 같은 열 수의 행만 가져야 함
+			records, err := csv.NewReader(&buf).ReadAll()
+			if err != nil {
+				t.Fatalf("output is not a single CSV table: %v\n%s", err, buf.String())
+			}
+			if len(records) != tt.wantRows+1 {
+				t.Fatalf("got %d records, want header + %d rows", len(records), tt.wantRows)
+			}
+			if records[0][0] != tt.wantHeader {
+				t.Errorf("header starts with %q, want %q", records[0][0], tt.wantHeader)
+			}
+		})
+	}
+}
+
+func TestWriteMarkdownTables(t *testing.T) {
+	tests := []struct {
+		table      string
+		wantTitles []string
+	}{
+		{"", []string{"### Image Pull Statistics", "### Registry Rate Limits"}},
+		{TableRateLimits, []string{"### Registry Rate Limits"}},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := testReport().Write(&buf, output.FormatMarkdown, tt.table); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		if got := strings.Count(buf.String(), "### "); got != len(tt.wantTitles) {
+			t.Errorf("table %q: got %d markdown tables, want %d", tt.table, got, len(tt.wantTitles))
+		}
+		for _, title := range tt.wantTitles {
+			if !strings.Contains(buf.String(), title) {
+				t.Errorf("table %q: output is missing %q", tt.table, title)
+			}
+		}
+	}
+}
+
+func TestParseTable(t *testing.T) {
+	for _, value := range []string{"", "images", "rate-limits", "RateLimits"} {
+		if _, err := ParseTable(value); err != nil {
+			t.Errorf("ParseTable(%q) error = %v", value, err)
+		}
+	}
+	if _, err := ParseTable("nodes"); err == nil {
+		t.Errorf("ParseTable(%q) error = nil, want error", "nodes")
+	}
+}
@@ -0,0 +1,531 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/collector"
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/ratelimit"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// 풀 통계 그룹 기준
+const (
+	GroupByImage     = "image"
+	GroupByReference = "reference"
+	GroupByRegistry  = "registry"
+	GroupByNode      = "node"
+)
+
+// PullGroup 그룹 기준 값 하나의 풀 횟수와 추정 전송량
+type PullGroup struct {
+	Key           string  `json:"key"`
+	Pulls         int     `json:"pulls"`
+	BytesPulled   int64   `json:"bytesPulled"`
+	EstimatedCost float64 `json:"estimatedCost"`
+}
+
+// PullsResponse /api/v1/pulls 응답
+type PullsResponse struct {
+	CollectedAt time.Time              `json:"collectedAt"`
+	PeriodHours int                    `json:"periodHours"`
+	GroupBy     string                 `json:"groupBy"`
+	Groups      []PullGroup            `json:"groups"`
+	Summary     kubernetes.PullSummary `json:"summary"`
+	// Images groupBy=image일 때 CLI와 같은 이미지별 통계 (사용 중 여부, 크기 포함)
+	Images []kubernetes.ImagePullStat `json:"images,omitempty"`
+}
+
+// TimelineBucket 시간 구간 하나의 풀 횟수
+type TimelineBucket struct {
+	Start      time.Time      `json:"start"`
+	Pulls      int            `json:"pulls"`
+	Registries map[string]int `json:"registries"`
+}
+
+// TimelineResponse /api/v1/pulls/timeline 응답 (시각을 모르는 풀 이벤트는 제외)
+type TimelineResponse struct {
+	CollectedAt time.Time        `json:"collectedAt"`
+	PeriodHours int              `json:"periodHours"`
+	StepSeconds int64            `json:"stepSeconds"`
+	Buckets     []TimelineBucket `json:"buckets"`
+}
+
+// maxTimelineBuckets step이 너무 작아 응답이 커지지 않도록 제한하는 구간 수
+const maxTimelineBuckets = 1000
+
+// InUseImage 실행 중인 컨테이너가 사용하는 이미지 하나
+type InUseImage struct {
+	Image      string   `json:"image"`
+	Registry   string   `json:"registry"`
+	References []string `json:"references"`
+	Digests    []string `json:"digests"`
+	Containers int      `json:"containers"`
+	Pods       int      `json:"pods"`
+	Namespaces []string `json:"namespaces"`
+	Nodes      []string `json:"nodes"`
+	// Pulls 수집 기간의 풀 횟수
+	Pulls int `json:"pulls"`
+}
+
+// InUseResponse /api/v1/images/in-use 응답
+type InUseResponse struct {
+	CollectedAt time.Time    `json:"collectedAt"`
+	Images      []InUseImage `json:"images"`
+}
+
+// NodeSummary 노드 하나의 실행 중인 컨테이너와 풀 횟수
+type NodeSummary struct {
+	Node       string `json:"node"`
+	Containers int    `json:"containers"`
+	Images     int    `json:"images"`
+	Pulls      int    `json:"pulls"`
+}
+
+// NodesResponse /api/v1/nodes 응답
+type NodesResponse struct {
+	CollectedAt time.Time     `json:"collectedAt"`
+	Nodes       []NodeSummary `json:"nodes"`
+}
+
+// NodeImage 노드에서 실행 중인 이미지 참조 하나
+type NodeImage struct {
+	Image      string   `json:"image"`
+	Name       string   `json:"name"`
+	Digest     string   `json:"digest,omitempty"`
+	Containers int      `json:"containers"`
+	Pods       []string `json:"pods"`
+}
+
+// NodeImagesResponse /api/v1/nodes/{node}/images 응답
+type NodeImagesResponse struct {
+	CollectedAt time.Time   `json:"collectedAt"`
+	Node        string      `json:"node"`
+	PeriodHours int         `json:"periodHours"`
+	Images      []NodeImage `json:"images"`
+	// Pulls 수집 기간에 이 노드에서 풀한 참조별 횟수
+	Pulls []PullGroup `json:"pulls"`
+}
+
+// RateLimitsResponse /api/v1/ratelimits 응답
+type RateLimitsResponse struct {
+	CollectedAt time.Time          `json:"collectedAt"`
+	RateLimits  []ratelimit.Record `json:"rateLimits"`
+}
+
+// apiError 처리 결과와 HTTP 상태 코드
+type apiError struct {
+	status  int
+	message string
+}
+
+// apiParams 엔드포인트가 사용하는 쿼리 파라미터를 확인한 값 (사용하지 않는 값은 0 또는 빈 문자열)
+type apiParams struct {
+	Hours   int
+	GroupBy string
+	Step    time.Duration
+}
+
+// key 경로와 확인된 파라미터로 만든 캐시 키
+// 원래 쿼리 문자열을 쓰지 않으므로 사용하지 않는 파라미터나 같은 값의 다른 표기(since=024)는 새 항목을 만들지 않음
+func (p apiParams) key(path string) string {
+	return fmt.Sprintf("%s?since=%d&groupBy=%s&step=%d", path, p.Hours, p.GroupBy, p.Step)
+}
+
+// maxCachedResponses 수집 결과 하나로 캐시하는 최대 응답 수 (step처럼 값의 범위가 넓은 파라미터 대비)
+const maxCachedResponses = 256
+
+// handleAPI 파라미터를 확인하여 마지막 수집 결과로 응답을 만들고, 다음 수집 전까지 같은 요청에는 만든 응답을 그대로 반환
+// parse는 엔드포인트가 사용하는 파라미터만 확인하며 (nil이면 파라미터 없음) 잘못된 값이면 400을 반환
+func (s *Server) handleAPI(
+	parse func(r *http.Request, snapshot *collector.Snapshot) (apiParams, *apiError),
+	build func(r *http.Request, snapshot *collector.Snapshot, params apiParams) (interface{}, *apiError),
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		snapshot := s.Collector.Snapshot()
+		if snapshot == nil {
+			writeError(w, http.StatusServiceUnavailable, "waiting for the first collection")
+			return
+		}
+
+		var params apiParams
+		if parse != nil {
+			var apiErr *apiError
+			if params, apiErr = parse(r, snapshot); apiErr != nil {
+				writeError(w, apiErr.status, apiErr.message)
+				return
+			}
+		}
+		key := params.key(r.URL.Path)
+		body, ok := s.cached(snapshot, key)
+		if !ok {
+			response, apiErr := build(r, snapshot, params)
+			if apiErr != nil {
+				writeError(w, apiErr.status, apiErr.message)
+				return
+			}
+			encoded, err := json.MarshalIndent(response, "", "  ")
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to encode response: %v", err))
+				return
+			}
+			body = append(encoded, '\n')
+			s.store(snapshot, key, body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Last-Modified", snapshot.CollectedAt.UTC().Format(http.TimeFormat))
+		w.Write(body)
+	}
+}
+
+// cached 같은 수집 결과로 만든 응답이 있으면 반환
+func (s *Server) cached(snapshot *collector.Snapshot, key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cacheSnapshot != snapshot {
+		return nil, false
+	}
+	body, ok := s.cache[key]
+	return body, ok
+}
+
+// store 응답을 저장 (새 수집 결과가 나오면 이전 응답은 모두 버리고, maxCachedResponses개가 차면 저장하지 않음)
+func (s *Server) store(snapshot *collector.Snapshot, key string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cacheSnapshot != snapshot {
+		s.cacheSnapshot = snapshot
+		s.cache = make(map[string][]byte)
+	}
+	if len(s.cache) >= maxCachedResponses {
+		return
+	}
+	s.cache[key] = body
+}
+
+// writeError {"error": "..."} 형식의 오류 응답
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+// groupKeys groupBy 값별 풀 이벤트의 그룹 키
+var groupKeys = map[string]func(kubernetes.PullEvent) string{
+	GroupByImage:     func(e kubernetes.PullEvent) string { return e.Reference.Name() },
+	GroupByReference: func(e kubernetes.PullEvent) string { return e.Reference.String() },
+	GroupByRegistry:  func(e kubernetes.PullEvent) string { return e.Reference.Registry },
+	GroupByNode:      func(e kubernetes.PullEvent) string { return e.Node },
+}
+
+// pullsParams /api/v1/pulls의 since와 groupBy를 확인 (groupBy 기본값 image)
+func pullsParams(r *http.Request, snapshot *collector.Snapshot) (apiParams, *apiError) {
+	hours, apiErr := sinceHours(r, snapshot)
+	if apiErr != nil {
+		return apiParams{}, apiErr
+	}
+	groupBy := r.URL.Query().Get("groupBy")
+	if groupBy == "" {
+		groupBy = GroupByImage
+	}
+	if _, ok := groupKeys[groupBy]; !ok {
+		return apiParams{}, &apiError{http.StatusBadRequest, fmt.Sprintf("invalid groupBy %q: expected image, reference, registry or node", groupBy)}
+	}
+	return apiParams{Hours: hours, GroupBy: groupBy}, nil
+}
+
+// pulls GET /api/v1/pulls?since=<hours>&groupBy=image|reference|registry|node
+func pulls(r *http.Request, snapshot *collector.Snapshot, params apiParams) (interface{}, *apiError) {
+	stats := snapshot.StatisticsSince(params.Hours)
+	response := PullsResponse{
+		CollectedAt: snapshot.CollectedAt,
+		PeriodHours: params.Hours,
+		GroupBy:     params.GroupBy,
+		Groups:      groupPulls(pullsSince(snapshot, params.Hours), stats, groupKeys[params.GroupBy]),
+		Summary:     stats.Summary,
+	}
+	if params.GroupBy == GroupByImage {
+		response.Images = stats.Images
+	}
+	return response, nil
+}
+
+// timelineParams /api/v1/pulls/timeline의 since와 step을 확인 (step 기본값 1h, 1m 이상, 구간 수 maxTimelineBuckets 이하)
+func timelineParams(r *http.Request, snapshot *collector.Snapshot) (apiParams, *apiError) {
+	hours, apiErr := sinceHours(r, snapshot)
+	if apiErr != nil {
+		return apiParams{}, apiErr
+	}
+	step := time.Hour
+	if value := r.URL.Query().Get("step"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed < time.Minute {
+			return apiParams{}, &apiError{http.StatusBadRequest, fmt.Sprintf("invalid step %q: expected a duration of at least 1m", value)}
+		}
+		step = parsed
+	}
+	if int(time.Duration(hours)*time.Hour/step) > maxTimelineBuckets {
+		return apiParams{}, &apiError{http.StatusBadRequest, fmt.Sprintf("step %s gives more than %d buckets for %d hours", step, maxTimelineBuckets, hours)}
+	}
+	return apiParams{Hours: hours, Step: step}, nil
+}
+
+// timeline GET /api/v1/pulls/timeline?since=<hours>&step=<duration>
+func timeline(r *http.Request, snapshot *collector.Snapshot, params apiParams) (interface{}, *apiError) {
+	hours, step := params.Hours, params.Step
+	period := time.Duration(hours) * time.Hour
+
+	// 구간 경계는 step 단위로 맞추고 마지막 구간에 수집 시각을 포함
+	end := snapshot.CollectedAt.Truncate(step).Add(step)
+	start := end.Add(-period).Truncate(step)
+	if start.After(snapshot.CollectedAt.Add(-period)) {
+		start = start.Add(-step)
+	}
+	response := TimelineResponse{
+		CollectedAt: snapshot.CollectedAt,
+		PeriodHours: hours,
+		StepSeconds: int64(step / time.Second),
+		Buckets:     []TimelineBucket{},
+	}
+	for t := start; t.Before(end); t = t.Add(step) {
+		response.Buckets = append(response.Buckets, TimelineBucket{Start: t, Registries: map[string]int{}})
+	}
+	from := snapshot.CollectedAt.Add(-period)
+	for _, event := range snapshot.Pulls {
+		if event.Time.IsZero() || event.Time.Before(from) || !event.Time.Before(end) {
+			continue
+		}
+		bucket := &response.Buckets[int(event.Time.Sub(start)/step)]
+		bucket.Pulls++
+		bucket.Registries[event.Reference.Registry]++
+	}
+	return response, nil
+}
+
+// sinceHours since 파라미터(시간)를 확인 (없으면 수집 기간 전체, 수집 기간보다 길면 오류)
+func sinceHours(r *http.Request, snapshot *collector.Snapshot) (int, *apiError) {
+	period := snapshot.Stats.PeriodHours
+	value := r.URL.Query().Get("since")
+	if value == "" {
+		return period, nil
+	}
+	hours, err := strconv.Atoi(value)
+	if err != nil || hours <= 0 {
+		return 0, &apiError{http.StatusBadRequest, fmt.Sprintf("invalid since %q: expected a positive number of hours", value)}
+	}
+	if hours > period {
+		return 0, &apiError{http.StatusBadRequest, fmt.Sprintf("since %d exceeds the collection window of %d hours (zim serve --since)", hours, period)}
+	}
+	return hours, nil
+}
+
+// pullsSince 마지막 hours 시간의 풀 이벤트 (시각을 모르는 이벤트는 포함)
+func pullsSince(snapshot *collector.Snapshot, hours int) []kubernetes.PullEvent {
+	from := snapshot.CollectedAt.Add(-time.Duration(hours) * time.Hour)
+	var events []kubernetes.PullEvent
+	for _, event := range snapshot.Pulls {
+		if event.Time.IsZero() || !event.Time.Before(from) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// groupPulls 풀 이벤트를 그룹별로 집계하고 이미지별 통계의 크기와 비용으로 전송량을 추정 (풀 횟수 순)
+func groupPulls(events []kubernetes.PullEvent, stats *kubernetes.PullStatistics, keyOf func(kubernetes.PullEvent) string) []PullGroup {
+	byImage := make(map[string]kubernetes.ImagePullStat)
+	for _, stat := range stats.Images {
+		byImage[stat.Image] = stat
+	}
+
+	index := make(map[string]int)
+	groups := []PullGroup{}
+	for _, event := range events {
+		key := keyOf(event)
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, PullGroup{Key: key})
+		}
+		groups[i].Pulls++
+		if stat, ok := byImage[event.Reference.Name()]; ok && stat.Pulls > 0 {
+			groups[i].BytesPulled += stat.SizeBytes
+			groups[i].EstimatedCost += stat.EstimatedCost / float64(stat.Pulls)
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Pulls != groups[j].Pulls {
+			return groups[i].Pulls > groups[j].Pulls
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	return groups
+}
+
+// imagesInUse GET /api/v1/images/in-use
+func imagesInUse(r *http.Request, snapshot *collector.Snapshot, params apiParams) (interface{}, *apiError) {
+	pullCounts := make(map[string]int)
+	for _, event := range snapshot.Pulls {
+		pullCounts[event.Reference.Name()]++
+	}
+
+	type imageSets struct {
+		image                                    InUseImage
+		references, digests, pods, spaces, nodes map[string]bool
+	}
+	byName := make(map[string]*imageSets)
+	for _, container := range snapshot.Containers {
+		name, registryHost := imageName(container.Image)
+		sets, ok := byName[name]
+		if !ok {
+			sets = &imageSets{
+				image:      InUseImage{Image: name, Registry: registryHost, Pulls: pullCounts[name]},
+				references: make(map[string]bool), digests: make(map[string]bool), pods: make(map[string]bool),
+				spaces: make(map[string]bool), nodes: make(map[string]bool),
+			}
+			byName[name] = sets
+		}
+		sets.image.Containers++
+		sets.references[container.Image] = true
+		if digest := container.Digest(); digest != "" {
+			sets.digests[digest] = true
+		}
+		sets.pods[container.Namespace+"/"+container.Pod] = true
+		sets.spaces[container.Namespace] = true
+		if container.Node != "" {
+			sets.nodes[container.Node] = true
+		}
+	}
+
+	response := InUseResponse{CollectedAt: snapshot.CollectedAt, Images: []InUseImage{}}
+	for _, sets := range byName {
+		image := sets.image
+		image.References = sortedKeys(sets.references)
+		image.Digests = sortedKeys(sets.digests)
+		image.Pods = len(sets.pods)
+		image.Namespaces = sortedKeys(sets.spaces)
+		image.Nodes = sortedKeys(sets.nodes)
+		response.Images = append(response.Images, image)
+	}
+	sort.Slice(response.Images, func(i, j int) bool {
+		if response.Images[i].Containers != response.Images[j].Containers {
+			return response.Images[i].Containers > response.Images[j].Containers
+		}
+		return response.Images[i].Image < response.Images[j].Image
+	})
+	return response, nil
+}
+
+// nodes GET /api/v1/nodes
+func nodes(r *http.Request, snapshot *collector.Snapshot, params apiParams) (interface{}, *apiError) {
+	summaries := make(map[string]*NodeSummary)
+	images := make(map[string]map[string]bool)
+	get := func(node string) *NodeSummary {
+		if summaries[node] == nil {
+			summaries[node] = &NodeSummary{Node: node}
+			images[node] = make(map[string]bool)
+		}
+		return summaries[node]
+	}
+	for _, container := range snapshot.Containers {
+		if container.Node == "" {
+			continue
+		}
+		get(container.Node).Containers++
+		images[container.Node][container.Image] = true
+	}
+	for _, event := range snapshot.Pulls {
+		get(event.Node).Pulls++
+	}
+
+	response := NodesResponse{CollectedAt: snapshot.CollectedAt, Nodes: []NodeSummary{}}
+	for node, summary := range summaries {
+		summary.Images = len(images[node])
+		response.Nodes = append(response.Nodes, *summary)
+	}
+	sort.Slice(response.Nodes, func(i, j int) bool { return response.Nodes[i].Node < response.Nodes[j].Node })
+	return response, nil
+}
+
+// nodeImages GET /api/v1/nodes/{node}/images
+func nodeImages(r *http.Request, snapshot *collector.Snapshot, params apiParams) (interface{}, *apiError) {
+	node := r.PathValue("node")
+	response := NodeImagesResponse{
+		CollectedAt: snapshot.CollectedAt,
+		Node:        node,
+		PeriodHours: snapshot.Stats.PeriodHours,
+		Images:      []NodeImage{},
+	}
+
+	index := make(map[string]int)
+	pods := make(map[string]map[string]bool)
+	for _, container := range snapshot.Containers {
+		if container.Node != node {
+			continue
+		}
+		key := container.Image + "@" + container.Digest()
+		i, ok := index[key]
+		if !ok {
+			i = len(response.Images)
+			index[key] = i
+			name, _ := imageName(container.Image)
+			response.Images = append(response.Images, NodeImage{Image: container.Image, Name: name, Digest: container.Digest()})
+			pods[key] = make(map[string]bool)
+		}
+		response.Images[i].Containers++
+		pods[key][container.Namespace+"/"+container.Pod] = true
+	}
+	for key, i := range index {
+		response.Images[i].Pods = sortedKeys(pods[key])
+	}
+	sort.Slice(response.Images, func(i, j int) bool {
+		if response.Images[i].Image != response.Images[j].Image {
+			return response.Images[i].Image < response.Images[j].Image
+		}
+		return response.Images[i].Digest < response.Images[j].Digest
+	})
+
+	var events []kubernetes.PullEvent
+	for _, event := range snapshot.Pulls {
+		if event.Node == node {
+			events = append(events, event)
+		}
+	}
+	response.Pulls = groupPulls(events, snapshot.Stats, func(e kubernetes.PullEvent) string { return e.Reference.String() })
+
+	if len(response.Images) == 0 && len(response.Pulls) == 0 {
+		return nil, &apiError{http.StatusNotFound, fmt.Sprintf("node %q has no running containers or pull events", node)}
+	}
+	return response, nil
+}
+
+// rateLimits GET /api/v1/ratelimits
+func rateLimits(r *http.Request, snapshot *collector.Snapshot, params apiParams) (interface{}, *apiError) {
+	return RateLimitsResponse{CollectedAt: snapshot.CollectedAt, RateLimits: ratelimit.Records(snapshot.RateLimits)}, nil
+}
+
+// imageName 이미지 참조를 정규화된 이름과 레지스트리로 변환 (파싱할 수 없으면 원래 문자열)
+func imageName(image string) (string, string) {
+	ref, err := registry.ParseReference(image)
+	if err != nil {
+		return image, ""
+	}
+	return ref.Name(), ref.Registry
+}
+
+// sortedKeys 집합의 값을 정렬하여 반환
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
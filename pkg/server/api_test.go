@@ -0,0 +1,143 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/collector"
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/metrics"
+)
+
+// fakeSource 고정된 수집 결과를 제공
+type fakeSource struct {
+	snapshot *collector.Snapshot
+}
+
+func (f *fakeSource) Snapshot() *collector.Snapshot { return f.snapshot }
+
+func (f *fakeSource) Metrics() []metrics.Family { return nil }
+
+func testServer(t *testing.T) *Server {
+	collectedAt := time.Now()
+	var lines []string
+	for i, image := range []string{"docker.io/library/nginx:1.25", "quay.io/calico/cni:v3.27.0", "docker.io/library/nginx:1.25"} {
+		at := collectedAt.Add(-time.Duration(i+1) * time.Hour)
+		lines = append(lines, fmt.Sprintf(`%s node-%d crio[581]: time="%s" level=info msg="Pulled image: %s" id=%d name=/runtime.v1.ImageService/PullImage`,
+			at.Format(time.Stamp), i%2, at.Format("2006-01-02 15:04:05.000000000-07:00"), image, i))
+	}
+	var events []kubernetes.PullEvent
+	for _, line := range lines {
+		event, ok := kubernetes.ParsePullEvent(line)
+		if !ok {
+			t.Fatalf("ParsePullEvent(%q) failed", line)
+		}
+		events = append(events, event)
+	}
+	snapshot := &collector.Snapshot{
+		CollectedAt: collectedAt,
+		PullEvents:  lines,
+		Pulls:       events,
+		Stats:       kubernetes.NewPullStatistics(lines, nil, kubernetes.PullStatisticsOptions{Since: 24}),
+	}
+	return New(&fakeSource{snapshot: snapshot})
+}
+
+func get(t *testing.T, handler http.Handler, target string) int {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", target, nil))
+	return recorder.Code
+}
+
+func TestAPICacheKeysOnlyUsedParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		targets []string
+	}{
+		{"nodes ignores all params", []string{
+			"/api/v1/nodes",
+			"/api/v1/nodes?since=1",
+			"/api/v1/nodes?groupBy=registry&step=5m",
+			"/api/v1/nodes?since=abc&foo=bar",
+		}},
+		{"in-use ignores all params", []string{
+			"/api/v1/images/in-use",
+			"/api/v1/images/in-use?since=12&groupBy=node",
+		}},
+		{"pulls ignores step and keys on parsed since", []string{
+			"/api/v1/pulls?since=24",
+			"/api/v1/pulls?since=024",
+			"/api/v1/pulls?since=24&step=5m",
+			"/api/v1/pulls?since=24&groupBy=image",
+			"/api/v1/pulls",
+		}},
+		{"timeline ignores groupBy and keys on parsed step", []string{
+			"/api/v1/pulls/timeline?step=30m",
+			"/api/v1/pulls/timeline?step=1800s",
+			"/api/v1/pulls/timeline?step=30m&groupBy=node",
+			"/api/v1/pulls/timeline?step=30m&since=24",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := testServer(t)
+			handler := s.Handler()
+			for _, target := range tt.targets {
+				if code := get(t, handler, target); code != http.StatusOK {
+					t.Fatalf("GET %s = %d, want 200", target, code)
+				}
+			}
+			if len(s.cache) != 1 {
+				t.Errorf("cache has %d entries after %d equivalent requests, want 1: %v", len(s.cache), len(tt.targets), cacheKeys(s))
+			}
+		})
+	}
+}
+
+func TestAPIRejectsInvalidParams(t *testing.T) {
+	s := testServer(t)
+	handler := s.Handler()
+	for _, target := range []string{
+		"/api/v1/pulls?since=abc",
+		"/api/v1/pulls?since=0",
+		"/api/v1/pulls?since=48",
+		"/api/v1/pulls?groupBy=namespace",
+		"/api/v1/pulls/timeline?step=10s",
+		"/api/v1/pulls/timeline?step=soon",
+		"/api/v1/pulls/timeline?step=1m",
+	} {
+		if code := get(t, handler, target); code != http.StatusBadRequest {
+			t.Errorf("GET %s = %d, want 400", target, code)
+		}
+	}
+	if len(s.cache) != 0 {
+		t.Errorf("cache has %d entries after invalid requests, want 0: %v", len(s.cache), cacheKeys(s))
+	}
+}
+
+func TestAPICacheLimit(t *testing.T) {
+	s := testServer(t)
+	handler := s.Handler()
+	// 서로 다른 유효한 step 값도 maxCachedResponses개까지만 캐시
+	for minutes := 2; minutes < 2+maxCachedResponses+10; minutes++ {
+		target := fmt.Sprintf("/api/v1/pulls/timeline?step=%dm", minutes)
+		if code := get(t, handler, target); code != http.StatusOK {
+			t.Fatalf("GET %s = %d, want 200", target, code)
+		}
+	}
+	if len(s.cache) != maxCachedResponses {
+		t.Errorf("cache has %d entries, want %d", len(s.cache), maxCachedResponses)
+	}
+}
+
+func cacheKeys(s *Server) []string {
+	var keys []string
+	for key := range s.cache {
+		keys = append(keys, key)
+	}
+	return keys
+}
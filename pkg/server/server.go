@@ -0,0 +1,76 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/collector"
+	"github.com/suslmk-lee/zim-image-management/pkg/metrics"
+)
+
+// Source 서버가 제공하는 수집 결과 (collector.Collector)
+type Source interface {
+	// Snapshot 마지막 수집 결과 (아직 수집하지 않았으면 nil)
+	Snapshot() *collector.Snapshot
+	// Metrics 누적 카운터와 마지막 수집 결과의 메트릭
+	Metrics() []metrics.Family
+}
+
+// Server 수집기의 결과를 HTTP로 제공
+type Server struct {
+	Collector Source
+
+	mu sync.Mutex
+	// cacheSnapshot, cache 마지막 수집 결과로 만든 API 응답 (요청 경로와 확인된 파라미터별)
+	cacheSnapshot *collector.Snapshot
+	cache         map[string][]byte
+}
+
+// New 수집기를 제공하는 서버를 생성
+func New(c Source) *Server {
+	return &Server{Collector: c}
+}
+
+// Handler 서버의 HTTP 핸들러
+//
+//	/                           대시보드 (pkg/server/web)
+//	/metrics                    Prometheus 메트릭
+//	/healthz                    첫 수집이 끝났으면 200, 아니면 503
+//	/api/v1/pulls               기간(since)과 그룹 기준(groupBy)별 풀 통계
+//	/api/v1/pulls/timeline      step 간격의 풀 횟수 (레지스트리별)
+//	/api/v1/images/in-use       실행 중인 이미지와 Pod, 노드
+//	/api/v1/nodes               노드별 컨테이너와 풀 횟수
+//	/api/v1/nodes/{node}/images 노드에서 실행 중인 이미지와 노드의 풀 이벤트
+//	/api/v1/ratelimits          레지스트리 rate limit
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /metrics", s.handleMetrics)
+	mux.HandleFunc("GET /healthz", s.handleHealth)
+	mux.HandleFunc("GET /api/v1/pulls", s.handleAPI(pullsParams, pulls))
+	mux.HandleFunc("GET /api/v1/pulls/timeline", s.handleAPI(timelineParams, timeline))
+	mux.HandleFunc("GET /api/v1/images/in-use", s.handleAPI(nil, imagesInUse))
+	mux.HandleFunc("GET /api/v1/nodes", s.handleAPI(nil, nodes))
+	mux.HandleFunc("GET /api/v1/nodes/{node}/images", s.handleAPI(nil, nodeImages))
+	mux.HandleFunc("GET /api/v1/ratelimits", s.handleAPI(nil, rateLimits))
+	mux.Handle("GET /", uiHandler())
+	return mux
+}
+
+// handleMetrics 누적 카운터와 마지막 수집 결과를 Prometheus text 형식으로 출력
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", metrics.TextContentType)
+	if err := metrics.WriteText(w, s.Collector.Metrics()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// handleHealth 첫 수집 전에는 503을 반환하여 readiness probe로 사용할 수 있게 함
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	snapshot := s.Collector.Snapshot()
+	if snapshot == nil {
+		http.Error(w, "waiting for the first collection", http.StatusServiceUnavailable)
+		return
+	}
+	fmt.Fprintf(w, "ok (last collection %s)\n", snapshot.CollectedAt.Format("2006-01-02 15:04:05"))
+}
@@ -0,0 +1,21 @@
+package server
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+// webFiles 대시보드 정적 파일 (외부 CDN 없이 바이너리에 포함)
+//
+//go:embed web
+var webFiles embed.FS
+
+// uiHandler 대시보드 정적 파일 핸들러 (/api/v1 응답을 브라우저에서 그림)
+func uiHandler() http.Handler {
+	files, err := fs.Sub(webFiles, "web")
+	if err != nil {
+		panic(err)
+	}
+	return http.FileServerFS(files)
+}
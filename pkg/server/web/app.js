@@ -0,0 +1,327 @@
+// ZIM dashboard: zim serve의 /api/v1 응답만 사용 (외부 스크립트 없음)
+"use strict";
+
+const REFRESH_MS = 60000;
+const COLORS = ["#2f6fde", "#2e9d5b", "#d9931a", "#8e44ad", "#d64545", "#16a2b8", "#7f8c8d", "#c0392b"];
+const SVG_NS = "http://www.w3.org/2000/svg";
+
+const state = {
+  images: [],
+  sortKey: "pulls",
+  sortDesc: true,
+  node: "",
+};
+
+// el 요소를 만들고 텍스트는 textContent로 넣음 (API 값은 HTML로 해석하지 않음)
+function el(tag, attrs, ...children) {
+  const node = document.createElement(tag);
+  for (const [name, value] of Object.entries(attrs || {})) {
+    node.setAttribute(name, value);
+  }
+  for (const child of children) {
+    node.append(child instanceof Node ? child : document.createTextNode(String(child)));
+  }
+  return node;
+}
+
+function svg(tag, attrs) {
+  const node = document.createElementNS(SVG_NS, tag);
+  for (const [name, value] of Object.entries(attrs || {})) {
+    node.setAttribute(name, value);
+  }
+  return node;
+}
+
+async function getJSON(path) {
+  const response = await fetch(path, { cache: "no-store" });
+  const body = await response.json();
+  if (!response.ok) {
+    throw new Error(body.error || response.statusText);
+  }
+  return body;
+}
+
+// formatBytes CLI와 같은 IEC 단위 (KiB, MiB, GiB)
+function formatBytes(bytes) {
+  if (!bytes) {
+    return "-";
+  }
+  const units = ["B", "KiB", "MiB", "GiB", "TiB"];
+  let value = bytes;
+  let unit = 0;
+  while (value >= 1024 && unit < units.length - 1) {
+    value /= 1024;
+    unit++;
+  }
+  return unit === 0 ? `${value} B` : `${value.toFixed(1)} ${units[unit]}`;
+}
+
+function formatCost(cost, bytes) {
+  return bytes ? `$${cost.toFixed(2)}` : "-";
+}
+
+function colorFor(name, names) {
+  return COLORS[names.indexOf(name) % COLORS.length];
+}
+
+function setStatus(text, isError) {
+  const status = document.getElementById("status");
+  status.textContent = text;
+  status.classList.toggle("error", Boolean(isError));
+}
+
+// 레지스트리 rate limit gauge
+function renderGauges(records) {
+  const container = document.getElementById("gauges");
+  container.replaceChildren();
+  for (const record of records) {
+    const gauge = el("div", { class: "gauge" });
+    const title = record.resource && record.resource !== "pulls" ? `${record.registry} (${record.resource})` : record.registry;
+    gauge.append(el("div", { class: "title", title: title }, title));
+
+    if (record.error) {
+      gauge.classList.add("failed");
+      gauge.append(el("div", { class: "value" }, record.error));
+      container.append(gauge);
+      continue;
+    }
+    if (!record.limit) {
+      gauge.append(el("div", { class: "value" }, record.throttled ? "Throttled" : "No limit reported"));
+      gauge.append(el("div", { class: "detail" }, record.note || record.identity || ""));
+      container.append(gauge);
+      continue;
+    }
+
+    const ratio = Math.max(0, Math.min(1, record.remaining / record.limit));
+    const color = ratio < 0.1 ? "var(--bad)" : ratio < 0.3 ? "var(--warn)" : "var(--ok)";
+    const arc = svg("svg", { viewBox: "0 0 120 70", width: "160", height: "90" });
+    const path = "M 10 60 A 50 50 0 0 1 110 60";
+    arc.append(svg("path", { d: path, fill: "none", stroke: "#e3e6eb", "stroke-width": "12" }));
+    const filled = svg("path", { d: path, fill: "none", stroke: color, "stroke-width": "12", pathLength: "100" });
+    filled.setAttribute("stroke-dasharray", `${ratio * 100} 100`);
+    arc.append(filled);
+    gauge.append(arc);
+    gauge.append(el("div", { class: "value" }, `${record.remaining} / ${record.limit}`));
+
+    const details = [record.unit, record.identity].filter(Boolean).join(" · ");
+    gauge.append(el("div", { class: "detail" }, details));
+    if (record.reset) {
+      gauge.append(el("div", { class: "detail" }, `resets ${new Date(record.reset).toLocaleString()}`));
+    }
+    container.append(gauge);
+  }
+  if (records.length === 0) {
+    container.append(el("p", { class: "empty" }, "No rate limit checks (zim serve --rate-limits=false)"));
+  }
+}
+
+// 레지스트리별로 쌓은 막대 차트
+function renderTimeline(timeline) {
+  const chart = document.getElementById("chart");
+  const legend = document.getElementById("chart-legend");
+  chart.replaceChildren();
+  legend.replaceChildren();
+
+  const buckets = timeline.buckets;
+  const registries = [...new Set(buckets.flatMap((bucket) => Object.keys(bucket.registries)))].sort();
+  const max = Math.max(1, ...buckets.map((bucket) => bucket.pulls));
+  const width = 800;
+  const height = 220;
+  const left = 36;
+  const bottom = 24;
+  const plotHeight = height - bottom - 8;
+  const barWidth = (width - left) / Math.max(1, buckets.length);
+
+  const root = svg("svg", { viewBox: `0 0 ${width} ${height}` });
+  root.append(svg("line", { class: "axis", x1: left, y1: height - bottom, x2: width, y2: height - bottom }));
+  for (const tick of [0, Math.ceil(max / 2), max]) {
+    const y = height - bottom - (tick / max) * plotHeight;
+    const label = svg("text", { x: left - 6, y: y + 4, "text-anchor": "end" });
+    label.textContent = tick;
+    root.append(label);
+  }
+
+  const labelEvery = Math.max(1, Math.ceil(buckets.length / 8));
+  buckets.forEach((bucket, i) => {
+    const x = left + i * barWidth;
+    let y = height - bottom;
+    for (const registry of registries) {
+      const count = bucket.registries[registry] || 0;
+      if (!count) {
+        continue;
+      }
+      const barHeight = (count / max) * plotHeight;
+      y -= barHeight;
+      const rect = svg("rect", {
+        x: x + 1, y: y, width: Math.max(1, barWidth - 2), height: barHeight, fill: colorFor(registry, registries),
+      });
+      const title = svg("title");
+      title.textContent = `${new Date(bucket.start).toLocaleString()}\n${registry}: ${count}`;
+      rect.append(title);
+      root.append(rect);
+    }
+    if (i % labelEvery === 0) {
+      const label = svg("text", { x: x + barWidth / 2, y: height - 8, "text-anchor": "middle" });
+      const start = new Date(bucket.start);
+      label.textContent = `${String(start.getHours()).padStart(2, "0")}:${String(start.getMinutes()).padStart(2, "0")}`;
+      root.append(label);
+    }
+  });
+  chart.append(root);
+
+  for (const registry of registries) {
+    const item = el("span", {}, registry);
+    item.style.setProperty("--swatch", colorFor(registry, registries));
+    legend.append(item);
+  }
+  if (registries.length === 0) {
+    legend.append(el("span", { class: "empty" }, "No timestamped pull events in this range"));
+  }
+}
+
+// 풀 통계 표 (정렬, 필터)
+function renderPullTable() {
+  const filter = document.getElementById("pull-filter").value.trim().toLowerCase();
+  const inUseOnly = document.getElementById("in-use-only").checked;
+  const rows = state.images
+    .filter((image) => !inUseOnly || image.inUse)
+    .filter((image) => !filter || image.image.toLowerCase().includes(filter) || image.registry.toLowerCase().includes(filter))
+    .sort((a, b) => {
+      const x = a[state.sortKey];
+      const y = b[state.sortKey];
+      const order = typeof x === "string" ? x.localeCompare(y) : Number(x) - Number(y);
+      return state.sortDesc ? -order : order;
+    });
+
+  const tbody = document.querySelector("#pull-table tbody");
+  tbody.replaceChildren(...rows.map((image) => el("tr", {},
+    el("td", {}, image.image),
+    el("td", {}, image.registry),
+    el("td", { class: "num" }, image.pulls),
+    el("td", {}, image.inUse ? "Yes" : "No"),
+    el("td", { class: "num" }, image.sizeBytes ? `${formatBytes(image.sizeBytes)} (${image.sizeSource})` : "-"),
+    el("td", { class: "num" }, (image.sizeBytes && !image.sizeCompressed ? "≤ " : "") + formatBytes(image.bytesPulled)),
+    el("td", { class: "num" }, formatCost(image.estimatedCost, image.bytesPulled)),
+  )));
+  if (rows.length === 0) {
+    tbody.append(el("tr", {}, el("td", { colspan: "7", class: "empty" }, "No pulls match")));
+  }
+
+  for (const th of document.querySelectorAll("#pull-table th")) {
+    th.classList.toggle("asc", th.dataset.key === state.sortKey && !state.sortDesc);
+    th.classList.toggle("desc", th.dataset.key === state.sortKey && state.sortDesc);
+  }
+}
+
+function renderPullSummary(pulls) {
+  const summary = pulls.summary;
+  const parts = [
+    `Last ${pulls.periodHours} hours`,
+    `${summary.totalPulls} pulls`,
+    `${summary.uniqueImages} images`,
+    `${summary.activeImages} in use`,
+  ];
+  // 압축 해제 크기를 쓴 이미지가 있으면 실제 전송량의 상한
+  const bound = summary.imagesUncompressed ? "≤ " : "";
+  if (summary.bytesPulled) {
+    parts.push(`${bound}${formatBytes(summary.bytesPulled)} pulls × size`);
+  }
+  if (summary.estimatedCost) {
+    parts.push(`${bound}$${summary.estimatedCost.toFixed(2)} estimated egress`);
+  }
+  document.getElementById("pull-summary").textContent = parts.join(" · ");
+}
+
+// 노드 선택 목록과 선택한 노드의 이미지
+function renderNodeOptions(nodes) {
+  const select = document.getElementById("node-select");
+  const names = nodes.nodes.map((node) => node.node);
+  if (!names.includes(state.node)) {
+    state.node = names[0] || "";
+  }
+  select.replaceChildren(...nodes.nodes.map((node) => {
+    const option = el("option", { value: node.node }, `${node.node} (${node.containers} containers, ${node.pulls} pulls)`);
+    option.selected = node.node === state.node;
+    return option;
+  }));
+}
+
+async function loadNode() {
+  const images = document.querySelector("#node-table tbody");
+  const pulls = document.querySelector("#node-pulls tbody");
+  if (!state.node) {
+    images.replaceChildren(el("tr", {}, el("td", { colspan: "4", class: "empty" }, "No nodes")));
+    pulls.replaceChildren();
+    return;
+  }
+  const node = await getJSON(`api/v1/nodes/${encodeURIComponent(state.node)}/images`);
+  images.replaceChildren(...node.images.map((image) => el("tr", {},
+    el("td", {}, image.image),
+    el("td", { class: "mono", title: image.digest || "" }, image.digest ? image.digest.slice(0, 19) : "-"),
+    el("td", { class: "num" }, image.containers),
+    el("td", {}, image.pods.join(", ")),
+  )));
+  if (node.images.length === 0) {
+    images.append(el("tr", {}, el("td", { colspan: "4", class: "empty" }, "No running containers")));
+  }
+  pulls.replaceChildren(...node.pulls.map((pull) => el("tr", {},
+    el("td", {}, pull.key),
+    el("td", { class: "num" }, pull.pulls),
+    el("td", { class: "num" }, formatBytes(pull.bytesPulled)),
+  )));
+  if (node.pulls.length === 0) {
+    pulls.append(el("tr", {}, el("td", { colspan: "3", class: "empty" }, `No pulls in the last ${node.periodHours} hours`)));
+  }
+}
+
+async function loadTimeline() {
+  const hours = document.getElementById("timeline-range").value;
+  const query = hours ? `?since=${hours}` : "";
+  try {
+    renderTimeline(await getJSON(`api/v1/pulls/timeline${query}`));
+  } catch (err) {
+    // 수집 기간보다 긴 범위는 전체 수집 기간으로 표시
+    renderTimeline(await getJSON("api/v1/pulls/timeline"));
+  }
+}
+
+async function refresh() {
+  try {
+    const [pulls, rateLimits, nodes] = await Promise.all([
+      getJSON("api/v1/pulls"),
+      getJSON("api/v1/ratelimits"),
+      getJSON("api/v1/nodes"),
+    ]);
+    state.images = pulls.images || [];
+    renderPullTable();
+    renderPullSummary(pulls);
+    renderGauges(rateLimits.rateLimits);
+    renderNodeOptions(nodes);
+    await Promise.all([loadTimeline(), loadNode()]);
+    setStatus(`Last collection ${new Date(pulls.collectedAt).toLocaleString()}`);
+  } catch (err) {
+    setStatus(err.message, true);
+  }
+}
+
+document.querySelectorAll("#pull-table th").forEach((th) => {
+  th.addEventListener("click", () => {
+    if (state.sortKey === th.dataset.key) {
+      state.sortDesc = !state.sortDesc;
+    } else {
+      state.sortKey = th.dataset.key;
+      state.sortDesc = th.dataset.numeric === "true";
+    }
+    renderPullTable();
+  });
+});
+document.getElementById("pull-filter").addEventListener("input", renderPullTable);
+document.getElementById("in-use-only").addEventListener("change", renderPullTable);
+document.getElementById("timeline-range").addEventListener("change", () => loadTimeline().catch((err) => setStatus(err.message, true)));
+document.getElementById("node-select").addEventListener("change", (event) => {
+  state.node = event.target.value;
+  loadNode().catch((err) => setStatus(err.message, true));
+});
+
+refresh();
+setInterval(refresh, REFRESH_MS);
@@ -0,0 +1,89 @@
+package signature
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// CountUnsigned 서명이 없거나 공개 키 검증에 실패한 워크로드 이미지 수
+func CountUnsigned(signatures []WorkloadSignature) int {
+	var unsigned int
+	for _, signature := range signatures {
+		if !signature.Signed() || signature.Verified == VerifyFailed {
+			unsigned++
+		}
+	}
+	return unsigned
+}
+
+// PrintSignatures 워크로드별 서명, attestation, 검증 상태와 요약 출력 (all이면 서명된 이미지도 포함)
+func PrintSignatures(signatures []WorkloadSignature, all bool) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "\nImage Signatures:\n")
+	fmt.Fprintln(w, "=======================================================================")
+	fmt.Fprintln(w, "No.\tNamespace\tWorkload\tImage\tDigest\tPods\tSigned\tAttested\tVerified")
+	fmt.Fprintln(w, "-----------------------------------------------------------------------")
+	no := 0
+	for _, signature := range signatures {
+		if !all && signature.Signed() && signature.Attested() && signature.Verified != VerifyFailed {
+			continue
+		}
+		no++
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\n", no, signature.Namespace, signature.Workload, signature.Image,
+			shortDigest(signature.Digest), signature.Pods, sources(signature.SignatureSources),
+			sources(signature.AttestationSources), orDash(signature.Verified))
+	}
+	fmt.Fprintln(w, "=======================================================================")
+	w.Flush()
+
+	// 요약 정보 출력
+	var unsigned, unattested, failed int
+	for _, signature := range signatures {
+		if !signature.Signed() {
+			unsigned++
+		}
+		if !signature.Attested() {
+			unattested++
+		}
+		if signature.Verified == VerifyFailed {
+			failed++
+		}
+	}
+	fmt.Printf("\nSummary:\n")
+	fmt.Printf("- Workload images checked: %d\n", len(signatures))
+	fmt.Printf("- Unsigned: %d\n", unsigned)
+	fmt.Printf("- Without attestations: %d\n", unattested)
+	fmt.Printf("- Failed verification: %d\n", failed)
+	for _, signature := range signatures {
+		if signature.Error != "" {
+			fmt.Printf("  - %s/%s (%s): %s\n", signature.Namespace, signature.Workload, signature.Image, signature.Error)
+		}
+	}
+}
+
+// sources 찾은 위치를 표시 (없으면 "No")
+func sources(values []string) string {
+	if len(values) == 0 {
+		return "No"
+	}
+	return "Yes (" + strings.Join(values, ",") + ")"
+}
+
+// shortDigest digest를 "sha256:" 이후 12자리로 줄여서 표시
+func shortDigest(digest string) string {
+	algorithm, hex, ok := strings.Cut(digest, ":")
+	if !ok || len(hex) <= 12 {
+		return orDash(digest)
+	}
+	return algorithm + ":" + hex[:12]
+}
+
+// orDash 빈 문자열을 "-"로 표시
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,219 @@
+package signature
+
+import (
+	"context"
+	"crypto"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// cosign 태그 접미사와 서명 레이어 annotation
+const (
+	SignatureTagSuffix   = ".sig"
+	AttestationTagSuffix = ".att"
+	SignatureAnnotation  = "dev.cosignproject.cosign/signature"
+)
+
+// referrers API에서 서명과 attestation을 구분하는 artifactType
+const (
+	ArtifactTypeCosignSignature = "application/vnd.dev.cosign.artifact.sig.v1+json"
+	ArtifactTypeNotarySignature = "application/vnd.cncf.notary.signature"
+	ArtifactTypeSigstoreBundle  = "application/vnd.dev.sigstore.bundle"
+	ArtifactTypeInToto          = "application/vnd.in-toto+json"
+	ArtifactTypeDSSE            = "application/vnd.dsse.envelope.v1+json"
+)
+
+// 서명 및 attestation을 찾은 위치
+const (
+	SourceTag       = "tag"
+	SourceReferrers = "referrers"
+)
+
+// 공개 키 검증 결과
+const (
+	VerifyNotChecked = "-"
+	VerifyPassed     = "verified"
+	VerifyFailed     = "failed"
+)
+
+// ImageSignature 이미지 digest 하나의 서명 및 attestation 확인 결과
+type ImageSignature struct {
+	Digest string
+	// SignatureSources 서명을 찾은 위치 (tag, referrers)
+	SignatureSources []string
+	// AttestationSources attestation을 찾은 위치 (tag, referrers)
+	AttestationSources []string
+	// Verified 공개 키 검증 결과 (키가 없으면 "-")
+	Verified string
+	// Error 조회 또는 검증 중 발생한 오류 메시지
+	Error string
+}
+
+// Signed 서명이 있는지 확인
+func (s ImageSignature) Signed() bool {
+	return len(s.SignatureSources) > 0
+}
+
+// Attested attestation이 있는지 확인
+func (s ImageSignature) Attested() bool {
+	return len(s.AttestationSources) > 0
+}
+
+// WorkloadSignature 워크로드가 실행 중인 이미지의 서명 상태
+type WorkloadSignature struct {
+	Namespace string
+	Workload  string
+	Image     string
+	Pods      int
+	ImageSignature
+}
+
+// Checker 이미지 digest의 cosign 서명 태그와 OCI referrers를 조회하여 서명 여부를 확인
+type Checker struct {
+	Client *registry.Client
+	// PublicKey 서명 검증에 사용할 공개 키 (nil이면 존재 여부만 확인)
+	PublicKey crypto.PublicKey
+	digests   map[string]*ImageSignature
+}
+
+// NewChecker 레지스트리 클라이언트와 공개 키(선택)로 Checker를 생성
+func NewChecker(client *registry.Client, publicKey crypto.PublicKey) *Checker {
+	return &Checker{Client: client, PublicKey: publicKey, digests: make(map[string]*ImageSignature)}
+}
+
+// Check 실행 중인 컨테이너를 워크로드/이미지/digest별로 묶어 서명과 attestation을 확인
+func (c *Checker) Check(ctx context.Context, containers []kubernetes.RunningContainer) []WorkloadSignature {
+	type groupKey struct{ namespace, workload, image, digest string }
+	groups := make(map[groupKey]*WorkloadSignature)
+	var keys []groupKey
+
+	for _, container := range containers {
+		key := groupKey{container.Namespace, container.Workload, container.Image, container.RepoDigest}
+		if group, ok := groups[key]; ok {
+			group.Pods++
+			continue
+		}
+		group := &WorkloadSignature{Namespace: container.Namespace, Workload: container.Workload, Image: container.Image, Pods: 1}
+		groups[key] = group
+		keys = append(keys, key)
+
+		ref, err := registry.ParseReference(container.Image)
+		if err != nil {
+			group.Error = err.Error()
+			continue
+		}
+		digest := container.RepoDigest
+		if digest == "" {
+			digest = ref.Digest
+		}
+		// imageID에 매니페스트 digest가 없는 런타임은 태그가 현재 가리키는 digest로 확인
+		if digest == "" {
+			desc, err := c.Client.HeadManifest(ctx, ref)
+			if err != nil {
+				group.Error = err.Error()
+				continue
+			}
+			digest = desc.Digest
+		}
+		group.ImageSignature = *c.checkDigest(ctx, ref, digest)
+	}
+
+	signatures := make([]WorkloadSignature, 0, len(keys))
+	for _, key := range keys {
+		signatures = append(signatures, *groups[key])
+	}
+	sort.Slice(signatures, func(i, j int) bool {
+		if signatures[i].Namespace != signatures[j].Namespace {
+			return signatures[i].Namespace < signatures[j].Namespace
+		}
+		if signatures[i].Workload != signatures[j].Workload {
+			return signatures[i].Workload < signatures[j].Workload
+		}
+		return signatures[i].Image < signatures[j].Image
+	})
+	return signatures
+}
+
+// checkDigest digest의 서명 태그, attestation 태그, referrers를 조회하고 공개 키로 검증 (결과는 캐시)
+func (c *Checker) checkDigest(ctx context.Context, ref registry.Reference, digest string) *ImageSignature {
+	cacheKey := ref.Name() + "@" + digest
+	if result, ok := c.digests[cacheKey]; ok {
+		return result
+	}
+	result := &ImageSignature{Digest: digest, Verified: VerifyNotChecked}
+	c.digests[cacheKey] = result
+
+	var errs []string
+	var signatureManifests []*registry.Manifest
+
+	// cosign 태그 스키마: sha256-<hex>.sig, sha256-<hex>.att
+	signatureTag, err := c.Client.GetManifest(ctx, ref.WithTag(registry.DigestTag(digest, SignatureTagSuffix)))
+	switch {
+	case err == nil:
+		result.SignatureSources = append(result.SignatureSources, SourceTag)
+		signatureManifests = append(signatureManifests, signatureTag)
+	case !registry.IsNotFound(err):
+		errs = append(errs, err.Error())
+	}
+	if _, err := c.Client.HeadManifest(ctx, ref.WithTag(registry.DigestTag(digest, AttestationTagSuffix))); err == nil {
+		result.AttestationSources = append(result.AttestationSources, SourceTag)
+	} else if !registry.IsNotFound(err) {
+		errs = append(errs, err.Error())
+	}
+
+	// OCI 1.1 referrers
+	referrers, err := c.Client.Referrers(ctx, ref, digest)
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+	for _, desc := range referrers {
+		switch {
+		case isAttestationType(desc.ArtifactType):
+			if !slices.Contains(result.AttestationSources, SourceReferrers) {
+				result.AttestationSources = append(result.AttestationSources, SourceReferrers)
+			}
+		case isSignatureType(desc.ArtifactType):
+			if !slices.Contains(result.SignatureSources, SourceReferrers) {
+				result.SignatureSources = append(result.SignatureSources, SourceReferrers)
+			}
+			if desc.ArtifactType == ArtifactTypeCosignSignature && c.PublicKey != nil {
+				if manifest, err := c.Client.GetManifest(ctx, ref.WithDigest(desc.Digest)); err == nil {
+					signatureManifests = append(signatureManifests, manifest)
+				}
+			}
+		}
+	}
+
+	if c.PublicKey != nil && result.Signed() {
+		result.Verified = VerifyFailed
+		for _, manifest := range signatureManifests {
+			if err := verifySignatureManifest(ctx, c.Client, ref, manifest, digest, c.PublicKey); err != nil {
+				errs = append(errs, "verification: "+err.Error())
+				continue
+			}
+			result.Verified = VerifyPassed
+			break
+		}
+		if result.Verified == VerifyPassed {
+			errs = nil
+		}
+	}
+	result.Error = strings.Join(errs, "; ")
+	return result
+}
+
+// isSignatureType 서명 아티팩트 타입인지 확인 (cosign, Notation, sigstore bundle)
+func isSignatureType(artifactType string) bool {
+	return artifactType == ArtifactTypeCosignSignature || artifactType == ArtifactTypeNotarySignature ||
+		strings.HasPrefix(artifactType, ArtifactTypeSigstoreBundle)
+}
+
+// isAttestationType attestation 아티팩트 타입인지 확인 (in-toto, DSSE)
+func isAttestationType(artifactType string) bool {
+	return artifactType == ArtifactTypeInToto || artifactType == ArtifactTypeDSSE ||
+		strings.Contains(artifactType, "in-toto") || strings.Contains(artifactType, "attestation")
+}
@@ -0,0 +1,127 @@
+package signature
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// maxPayloadSize 서명 payload blob의 최대 크기
+const maxPayloadSize = 1024 * 1024
+
+// simpleSigningPayload cosign 서명 대상 payload 중 이미지 digest 부분
+type simpleSigningPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// LoadPublicKey PEM 형식(PKIX "PUBLIC KEY")의 ECDSA, RSA 또는 Ed25519 공개 키를 읽음 (cosign.pub)
+func LoadPublicKey(path string) (crypto.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key: %v", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in %s", path)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %v", err)
+	}
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	}
+	return nil, fmt.Errorf("unsupported public key type %T", key)
+}
+
+// verifySignatureManifest 서명 매니페스트의 레이어 중 하나라도 공개 키로 검증되고 이미지 digest를 가리키는지 확인
+func verifySignatureManifest(ctx context.Context, client *registry.Client, ref registry.Reference, manifest *registry.Manifest, digest string, key crypto.PublicKey) error {
+	var lastErr error = fmt.Errorf("no cosign signature layers")
+	for _, layer := range manifest.Layers {
+		encoded := layer.Annotations[SignatureAnnotation]
+		if encoded == "" {
+			continue
+		}
+		if err := verifyLayer(ctx, client, ref, layer, encoded, digest, key); err != nil {
+			lastErr = err
+			continue
+		}
+		return nil
+	}
+	return lastErr
+}
+
+// verifyLayer payload blob의 서명을 검증하고 payload가 이미지 digest를 가리키는지 확인
+func verifyLayer(ctx context.Context, client *registry.Client, ref registry.Reference, layer registry.Descriptor, encoded, digest string, key crypto.PublicKey) error {
+	signature, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %v", err)
+	}
+
+	body, _, err := client.GetBlob(ctx, ref, layer.Digest)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	payload, err := io.ReadAll(io.LimitReader(body, maxPayloadSize))
+	if err != nil {
+		return fmt.Errorf("failed to read signature payload: %v", err)
+	}
+	if registry.Digest(payload) != layer.Digest {
+		return fmt.Errorf("signature payload digest mismatch")
+	}
+
+	if err := verifyBytes(key, payload, signature); err != nil {
+		return err
+	}
+
+	var simpleSigning simpleSigningPayload
+	if err := json.Unmarshal(payload, &simpleSigning); err != nil {
+		return fmt.Errorf("failed to decode signature payload: %v", err)
+	}
+	if signed := simpleSigning.Critical.Image.DockerManifestDigest; signed != digest {
+		return fmt.Errorf("signature is for %s, not %s", signed, digest)
+	}
+	return nil
+}
+
+// verifyBytes 공개 키 종류에 맞게 payload의 서명을 검증 (ECDSA/RSA는 SHA-256 해시 사용)
+func verifyBytes(key crypto.PublicKey, payload, signature []byte) error {
+	hash := sha256.Sum256(payload)
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, hash[:], signature) {
+			return fmt.Errorf("invalid ECDSA signature")
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], signature); err != nil {
+			if err := rsa.VerifyPSS(key, crypto.SHA256, hash[:], signature, nil); err != nil {
+				return fmt.Errorf("invalid RSA signature")
+			}
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, payload, signature) {
+			return fmt.Errorf("invalid Ed25519 signature")
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+	return nil
+}
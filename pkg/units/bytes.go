@@ -0,0 +1,17 @@
+package units
+
+import "fmt"
+
+// FormatBytes 바이트 수를 KiB/MiB/GiB 단위의 문자열로 변환
+func FormatBytes(value int64) string {
+	const unit = 1024
+	if value < unit {
+		return fmt.Sprintf("%d B", value)
+	}
+	div, exp := int64(unit), 0
+	for n := value / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(value)/float64(div), "KMGTPE"[exp])
+}
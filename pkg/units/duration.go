@@ -0,0 +1,26 @@
+package units
+
+import (
+	"fmt"
+	"time"
+)
+
+// FormatAge 경과 시간을 "3d4h", "5h12m", "42m" 형식의 짧은 문자열로 변환
+func FormatAge(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	days := int64(d / (24 * time.Hour))
+	hours := int64(d/time.Hour) % 24
+	minutes := int64(d/time.Minute) % 60
+	switch {
+	case days >= 10:
+		return fmt.Sprintf("%dd", days)
+	case days > 0:
+		return fmt.Sprintf("%dd%dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
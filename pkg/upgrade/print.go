@@ -0,0 +1,109 @@
+package upgrade
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+)
+
+// PrintUpgrades 워크로드별 새 버전 목록과 요약 출력 (all이면 최신 버전을 사용하는 이미지도 포함)
+func PrintUpgrades(updates []Update, all bool) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "\nAvailable Image Upgrades:\n")
+	fmt.Fprintln(w, "=======================================================================")
+	fmt.Fprintln(w, "No.\tNamespace\tWorkload\tImage\tCurrent\tPatch\tMinor\tMajor")
+	fmt.Fprintln(w, "-----------------------------------------------------------------------")
+	no := 0
+	for _, update := range updates {
+		if !all && !update.HasUpdate() {
+			continue
+		}
+		no++
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", no, update.Namespace, update.Workload, update.Image,
+			update.Current, orDash(update.Patch), orDash(update.Minor), orDash(update.Major))
+	}
+	fmt.Fprintln(w, "=======================================================================")
+	w.Flush()
+
+	// 이미지별 요약 (같은 이미지:태그를 여러 워크로드가 사용하면 한 번만 집계)
+	type imageSummary struct {
+		update    Update
+		workloads int
+		pods      int
+	}
+	summaries := make(map[string]*imageSummary)
+	var keys []string
+	var failed []Update
+	failedImages := make(map[string]bool)
+	for _, update := range updates {
+		if update.Error != "" {
+			if !failedImages[update.Image] {
+				failedImages[update.Image] = true
+				failed = append(failed, update)
+			}
+			continue
+		}
+		if !update.HasUpdate() {
+			continue
+		}
+		key := update.Image + ":" + update.Current
+		summary, ok := summaries[key]
+		if !ok {
+			summary = &imageSummary{update: update}
+			summaries[key] = summary
+			keys = append(keys, key)
+		}
+		summary.workloads++
+		summary.pods += update.Pods
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return summaries[keys[i]].pods > summaries[keys[j]].pods
+	})
+
+	if len(keys) > 0 {
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
+		fmt.Fprintln(w, "\nImage\tCurrent\tLatest\tWorkloads\tPods")
+		fmt.Fprintln(w, "-----------------------------------------------------------------------")
+		for _, key := range keys {
+			summary := summaries[key]
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\n", summary.update.Image, summary.update.Current,
+				latest(summary.update), summary.workloads, summary.pods)
+		}
+		w.Flush()
+	}
+
+	var withUpdates int
+	for _, update := range updates {
+		if update.HasUpdate() {
+			withUpdates++
+		}
+	}
+	fmt.Printf("\nSummary:\n")
+	fmt.Printf("- Workload images checked: %d\n", len(updates))
+	fmt.Printf("- With newer versions: %d (%d distinct images)\n", withUpdates, len(keys))
+	if len(failed) > 0 {
+		fmt.Printf("- Images with failed tag lookups: %d\n", len(failed))
+		for _, update := range failed {
+			fmt.Printf("  - %s: %s\n", update.Image, update.Error)
+		}
+	}
+}
+
+// latest 가장 큰 업데이트 (major > minor > patch)
+func latest(update Update) string {
+	for _, tag := range []string{update.Major, update.Minor, update.Patch} {
+		if tag != "" {
+			return tag
+		}
+	}
+	return update.Current
+}
+
+// orDash 빈 문자열을 "-"로 표시
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,160 @@
+package upgrade
+
+import (
+	"context"
+	"regexp"
+	"sort"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// Update 워크로드 이미지 하나에 대해 찾은 새 버전
+type Update struct {
+	Namespace string
+	Workload  string
+	Image     string
+	Pods      int
+	Current   string
+	// Patch 같은 major.minor에서 가장 높은 새 버전
+	Patch string
+	// Minor 같은 major에서 minor가 더 높은 가장 높은 버전
+	Minor string
+	// Major major가 더 높은 가장 높은 버전
+	Major string
+	// Error 태그 목록 조회에 실패한 경우의 오류 메시지
+	Error string
+}
+
+// HasUpdate 새 버전이 하나라도 있는지 확인
+func (u Update) HasUpdate() bool {
+	return u.Patch != "" || u.Minor != "" || u.Major != ""
+}
+
+// Checker 레지스트리 태그 목록에서 사용 중인 이미지의 새 버전을 찾음
+type Checker struct {
+	Client *registry.Client
+	// Registries 확인할 레지스트리 (비어 있으면 전체)
+	Registries map[string]bool
+	// ExcludeRegistries 확인하지 않을 레지스트리
+	ExcludeRegistries map[string]bool
+	// Patterns 이미지 이름(레지스트리/저장소)별로 후보 태그가 일치해야 하는 정규식
+	Patterns map[string]*regexp.Regexp
+	tags     map[string]tagList
+}
+
+// tagList 저장소별 태그 목록 조회 결과 (저장소마다 한 번만 조회)
+type tagList struct {
+	tags []string
+	err  error
+}
+
+// NewChecker 레지스트리 클라이언트로 Checker를 생성
+func NewChecker(client *registry.Client) *Checker {
+	return &Checker{
+		Client:            client,
+		Registries:        make(map[string]bool),
+		ExcludeRegistries: make(map[string]bool),
+		Patterns:          make(map[string]*regexp.Regexp),
+		tags:              make(map[string]tagList),
+	}
+}
+
+// Check semver 형식 태그를 사용하는 워크로드 이미지마다 새 patch/minor/major 버전을 조회
+func (c *Checker) Check(ctx context.Context, images []kubernetes.WorkloadImage) []Update {
+	var updates []Update
+	for _, image := range images {
+		ref, err := registry.ParseReference(image.Image)
+		if err != nil || ref.Digest != "" || ref.Tag == "" {
+			continue
+		}
+		if len(c.Registries) > 0 && !c.Registries[ref.Registry] {
+			continue
+		}
+		if c.ExcludeRegistries[ref.Registry] {
+			continue
+		}
+		pattern := c.Patterns[ref.Name()]
+		if pattern != nil && !pattern.MatchString(ref.Tag) {
+			continue
+		}
+		current, ok := ParseVersion(ref.Tag)
+		if !ok {
+			continue
+		}
+
+		update := Update{
+			Namespace: image.Namespace,
+			Workload:  image.Workload,
+			Image:     ref.Name(),
+			Pods:      image.Pods,
+			Current:   ref.Tag,
+		}
+		list := c.listTags(ctx, ref)
+		if list.err != nil {
+			update.Error = list.err.Error()
+		} else {
+			update.Patch, update.Minor, update.Major = FindUpdates(current, list.tags, pattern)
+		}
+		updates = append(updates, update)
+	}
+
+	sort.Slice(updates, func(i, j int) bool {
+		if updates[i].Namespace != updates[j].Namespace {
+			return updates[i].Namespace < updates[j].Namespace
+		}
+		if updates[i].Workload != updates[j].Workload {
+			return updates[i].Workload < updates[j].Workload
+		}
+		return updates[i].Image < updates[j].Image
+	})
+	return updates
+}
+
+// listTags 저장소의 태그 목록을 조회 (결과는 캐시)
+func (c *Checker) listTags(ctx context.Context, ref registry.Reference) tagList {
+	if list, ok := c.tags[ref.Name()]; ok {
+		return list
+	}
+	tags, err := c.Client.ListTags(ctx, ref)
+	list := tagList{tags: tags, err: err}
+	c.tags[ref.Name()] = list
+	return list
+}
+
+// FindUpdates 현재 버전과 같은 형태의 태그 중 가장 높은 patch, minor, major 업데이트를 반환 (없으면 빈 문자열)
+func FindUpdates(current Version, tags []string, pattern *regexp.Regexp) (string, string, string) {
+	var patch, minor, major *Version
+	for _, tag := range tags {
+		if pattern != nil && !pattern.MatchString(tag) {
+			continue
+		}
+		candidate, ok := ParseVersion(tag)
+		if !ok || !current.Comparable(candidate) || candidate.Compare(current) <= 0 {
+			continue
+		}
+
+		var best **Version
+		switch {
+		case candidate.Major() != current.Major():
+			best = &major
+		case candidate.Minor() != current.Minor():
+			best = &minor
+		default:
+			best = &patch
+		}
+		if *best == nil || candidate.Compare(**best) > 0 {
+			c := candidate
+			*best = &c
+		}
+	}
+	return versionTag(patch), versionTag(minor), versionTag(major)
+}
+
+// versionTag 버전의 태그 (nil이면 빈 문자열)
+func versionTag(v *Version) string {
+	if v == nil {
+		return ""
+	}
+	return v.Tag
+}
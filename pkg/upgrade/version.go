@@ -0,0 +1,123 @@
+package upgrade
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// suffixNumberPattern 변형 안의 숫자 (예: debian-12-r5의 12, 5)
+var suffixNumberPattern = regexp.MustCompile(`\d+`)
+
+// versionPattern semver 형식 태그 (예: 1.25, v1.25.3, 1.25.3-alpine, 3.18.4-debian-12-r5)
+var versionPattern = regexp.MustCompile(`^(v?)(\d+)\.(\d+)(?:\.(\d+))?(?:[-+_](.*))?$`)
+
+// Version 태그에서 파싱한 버전
+type Version struct {
+	Tag string
+	// Prefix "v" 접두사
+	Prefix string
+	// Numbers major, minor와 (있으면) patch
+	Numbers []int
+	// Suffix 버전 뒤의 변형 또는 빌드 정보 (예: "alpine")
+	Suffix string
+}
+
+// ParseVersion semver 형식 태그를 파싱 (형식이 다르면 false)
+func ParseVersion(tag string) (Version, bool) {
+	match := versionPattern.FindStringSubmatch(tag)
+	if match == nil {
+		return Version{}, false
+	}
+	v := Version{Tag: tag, Prefix: match[1], Suffix: match[5]}
+	for _, part := range match[2:5] {
+		if part == "" {
+			continue
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return Version{}, false
+		}
+		v.Numbers = append(v.Numbers, n)
+	}
+	return v, true
+}
+
+// Major 주 버전
+func (v Version) Major() int {
+	return v.Numbers[0]
+}
+
+// Minor 부 버전
+func (v Version) Minor() int {
+	return v.Numbers[1]
+}
+
+// Comparable 같은 형태(접두사, 자릿수, 변형)의 태그끼리만 비교 (1.25.3-alpine은 1.26.0-alpine과 비교하고 1.26.0-rc1과는 비교하지 않음)
+func (v Version) Comparable(other Version) bool {
+	return v.Prefix == other.Prefix && len(v.Numbers) == len(other.Numbers) && suffixShape(v.Suffix) == suffixShape(other.Suffix)
+}
+
+// Compare 숫자 부분을 비교하여 -1, 0, 1을 반환
+// 숫자 부분이 같고 변형의 형태가 같으면 변형 안의 숫자를 차례로 비교 (1.25.3-debian-12-r6 > 1.25.3-debian-12-r5)
+func (v Version) Compare(other Version) int {
+	for i := 0; i < len(v.Numbers) && i < len(other.Numbers); i++ {
+		if v.Numbers[i] != other.Numbers[i] {
+			if v.Numbers[i] < other.Numbers[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case len(v.Numbers) < len(other.Numbers):
+		return -1
+	case len(v.Numbers) > len(other.Numbers):
+		return 1
+	}
+	if suffixShape(v.Suffix) != suffixShape(other.Suffix) {
+		return 0
+	}
+	ours := suffixNumberPattern.FindAllString(v.Suffix, -1)
+	theirs := suffixNumberPattern.FindAllString(other.Suffix, -1)
+	for i := 0; i < len(ours) && i < len(theirs); i++ {
+		if c := compareDigits(ours[i], theirs[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(ours) < len(theirs):
+		return -1
+	case len(ours) > len(theirs):
+		return 1
+	}
+	return 0
+}
+
+// compareDigits 숫자 문자열을 크기로 비교 (앞의 0은 무시, 자릿수 제한 없음)
+func compareDigits(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// String 원래 태그
+func (v Version) String() string {
+	return v.Tag
+}
+
+// suffixShape 변형에서 숫자를 제거한 형태 (alpine3.18과 alpine3.19, debian-12-r5와 debian-12-r6을 같은 형태로 취급)
+func suffixShape(suffix string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return -1
+		}
+		return r
+	}, suffix)
+}
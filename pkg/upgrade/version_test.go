@@ -0,0 +1,54 @@
+package upgrade
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.25.3", "1.25.4", -1},
+		{"1.26.0", "1.25.9", 1},
+		{"1.25", "1.25.0", -1},
+		{"1.25.3-alpine", "1.25.3-alpine", 0},
+		// 숫자 부분이 같으면 같은 형태의 변형 안의 숫자로 비교
+		{"1.25.3-debian-12-r5", "1.25.3-debian-12-r6", -1},
+		{"1.25.3-debian-12-r10", "1.25.3-debian-12-r9", 1},
+		{"1.25.3-debian-12-r05", "1.25.3-debian-12-r5", 0},
+		{"3.18.4-alpine3.19", "3.18.4-alpine3.18", 1},
+		{"1.25.3-r", "1.25.3-r1", -1},
+		// 숫자 부분이 다르면 변형은 보지 않음
+		{"1.25.4-debian-12-r0", "1.25.3-debian-12-r9", 1},
+		// 형태가 다른 변형은 비교하지 않음
+		{"1.25.3-alpine", "1.25.3-rc1", 0},
+	}
+	for _, tt := range tests {
+		a, okA := ParseVersion(tt.a)
+		b, okB := ParseVersion(tt.b)
+		if !okA || !okB {
+			t.Fatalf("ParseVersion(%q, %q) failed", tt.a, tt.b)
+		}
+		if got := a.Compare(b); got != tt.want {
+			t.Errorf("%s.Compare(%s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := b.Compare(a); got != -tt.want {
+			t.Errorf("%s.Compare(%s) = %d, want %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
+
+func TestFindUpdatesRevision(t *testing.T) {
+	current, _ := ParseVersion("1.25.3-debian-12-r5")
+	tags := []string{"1.25.3-debian-12-r7", "1.25.3-debian-12-r4", "1.25.3-debian-12-r6", "1.25.3-alpine", "1.26.1-debian-12-r0", "1.26.1-debian-12-r2"}
+	reversed := slices.Clone(tags)
+	slices.Reverse(reversed)
+	for _, order := range [][]string{tags, reversed} {
+		patch, minor, major := FindUpdates(current, order, nil)
+		if patch != "1.25.3-debian-12-r7" || minor != "1.26.1-debian-12-r2" || major != "" {
+			t.Errorf("FindUpdates(%v) = %q, %q, %q, want revision and minor updates", order, patch, minor, major)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package vulns
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/suslmk-lee/zim-image-management/pkg/kubernetes"
+	"github.com/suslmk-lee/zim-image-management/pkg/registry"
+)
+
+// 리포트와 이미지를 연결한 방법
+const (
+	MatchDigest    = "digest"
+	MatchReference = "reference"
+)
+
+// ImageExposure 사용 중인 이미지에 연결된 리포트와 실행/풀 규모
+type ImageExposure struct {
+	// Image 클러스터에서 사용하는 이미지 참조
+	Image  string
+	Report *Report
+	// Match 리포트를 연결한 방법 (digest 또는 reference)
+	Match string
+	// Pods 이미지를 실행 중인 Pod 수
+	Pods int
+	// Workloads 이미지를 사용하는 "namespace/Kind/Name" 목록
+	Workloads []string
+	// Pulls 조회 기간 동안의 풀 횟수 (저장소 기준)
+	Pulls int
+	// Counts 심각도별 취약점 수 (같은 ID와 패키지는 한 번만 집계)
+	Counts  map[string]int
+	Fixable int
+}
+
+// SeverityScore 심각도 가중치 합계
+func (e ImageExposure) SeverityScore() int {
+	var score int
+	for severity, count := range e.Counts {
+		score += severityWeights[severity] * count
+	}
+	return score
+}
+
+// Risk 심각도 점수에 실행 중인 Pod 수와 풀 횟수를 곱한 우선순위 점수
+func (e ImageExposure) Risk() int {
+	return e.SeverityScore() * (e.Pods + e.Pulls)
+}
+
+// Total 전체 취약점 수
+func (e ImageExposure) Total() int {
+	var total int
+	for _, count := range e.Counts {
+		total += count
+	}
+	return total
+}
+
+// MatchReports 리포트를 실행 중인 컨테이너와 digest(우선) 또는 이미지 참조로 연결
+// 연결된 이미지 목록, 어떤 컨테이너와도 연결되지 않은 리포트, 리포트가 없는 이미지 목록을 반환
+func MatchReports(reports []*Report, containers []kubernetes.RunningContainer, pullCounts map[string]int) ([]ImageExposure, []*Report, []string) {
+	byDigest := make(map[string]*Report)
+	byReference := make(map[string]*Report)
+	for _, report := range reports {
+		for _, digest := range report.Digests {
+			byDigest[digest] = report
+		}
+		if key := referenceKey(report.Image); key != "" {
+			byReference[key] = report
+		}
+	}
+
+	type exposureKey struct {
+		report *Report
+		image  string
+	}
+	exposures := make(map[exposureKey]*ImageExposure)
+	var keys []exposureKey
+	matchedReports := make(map[*Report]bool)
+	unmatchedImages := make(map[string]bool)
+
+	for _, container := range containers {
+		match := MatchDigest
+		report := byDigest[container.RepoDigest]
+		if report == nil {
+			report = byDigest[container.ConfigDigest]
+		}
+		if report == nil {
+			report, match = byReference[referenceKey(container.Image)], MatchReference
+		}
+		if report == nil {
+			unmatchedImages[container.Image] = true
+			continue
+		}
+		matchedReports[report] = true
+
+		key := exposureKey{report, container.Image}
+		exposure, ok := exposures[key]
+		if !ok {
+			exposure = &ImageExposure{Image: container.Image, Report: report, Match: match, Counts: countSeverities(report)}
+			for _, v := range uniqueVulnerabilities(report) {
+				if v.Fixable() {
+					exposure.Fixable++
+				}
+			}
+			if ref, err := registry.ParseReference(container.Image); err == nil {
+				exposure.Pulls = pullCounts[ref.Name()]
+			}
+			exposures[key] = exposure
+			keys = append(keys, key)
+		}
+		// digest로 연결된 컨테이너가 하나라도 있으면 digest 일치로 표시
+		if match == MatchDigest {
+			exposure.Match = MatchDigest
+		}
+		exposure.Pods++
+		workload := container.Namespace + "/" + container.Workload
+		if !slices.Contains(exposure.Workloads, workload) {
+			exposure.Workloads = append(exposure.Workloads, workload)
+		}
+	}
+
+	result := make([]ImageExposure, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, *exposures[key])
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Risk() != result[j].Risk() {
+			return result[i].Risk() > result[j].Risk()
+		}
+		return result[i].Image < result[j].Image
+	})
+
+	var unmatched []*Report
+	for _, report := range reports {
+		if !matchedReports[report] {
+			unmatched = append(unmatched, report)
+		}
+	}
+	var missing []string
+	for image := range unmatchedImages {
+		missing = append(missing, image)
+	}
+	sort.Strings(missing)
+	return result, unmatched, missing
+}
+
+// referenceKey 이미지 참조를 비교 가능한 형태로 정규화 (태그가 없으면 latest)
+func referenceKey(image string) string {
+	if image == "" {
+		return ""
+	}
+	ref, err := registry.ParseReference(image)
+	if err != nil {
+		return ""
+	}
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = "latest"
+	}
+	return ref.String()
+}
+
+// uniqueVulnerabilities 같은 취약점 ID와 패키지의 중복을 제거 (여러 대상에서 보고된 경우)
+func uniqueVulnerabilities(report *Report) []Vulnerability {
+	seen := make(map[string]bool)
+	var unique []Vulnerability
+	for _, v := range report.Vulnerabilities {
+		key := v.ID + " " + v.Package + " " + v.InstalledVersion
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, v)
+	}
+	return unique
+}
+
+// countSeverities 심각도별 취약점 수
+func countSeverities(report *Report) map[string]int {
+	counts := make(map[string]int)
+	for _, v := range uniqueVulnerabilities(report) {
+		counts[v.Severity]++
+	}
+	return counts
+}
@@ -0,0 +1,342 @@
+package vulns
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// LoadReport 파일에서 Trivy/Grype JSON 또는 CycloneDX/SPDX JSON SBOM을 읽음
+func LoadReport(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report: %v", err)
+	}
+	report, err := ParseReport(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	report.Path = path
+	return report, nil
+}
+
+// ParseReport 최상위 필드로 형식을 판별하여 리포트를 파싱
+func ParseReport(data []byte) (*Report, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode report: %v", err)
+	}
+
+	switch {
+	case fields["bomFormat"] != nil:
+		return parseCycloneDX(data)
+	case fields["spdxVersion"] != nil:
+		return parseSPDX(data)
+	case fields["matches"] != nil:
+		return parseGrype(data)
+	case fields["ArtifactName"] != nil || fields["Results"] != nil:
+		return parseTrivy(data)
+	}
+	return nil, fmt.Errorf("unrecognized report format (expected Trivy, Grype, CycloneDX or SPDX JSON)")
+}
+
+// trivyReport Trivy JSON 리포트 중 필요한 필드
+type trivyReport struct {
+	ArtifactName string `json:"ArtifactName"`
+	Metadata     struct {
+		ImageID     string   `json:"ImageID"`
+		RepoDigests []string `json:"RepoDigests"`
+		RepoTags    []string `json:"RepoTags"`
+	} `json:"Metadata"`
+	Results []struct {
+		Target          string `json:"Target"`
+		Vulnerabilities []struct {
+			VulnerabilityID  string `json:"VulnerabilityID"`
+			PkgName          string `json:"PkgName"`
+			InstalledVersion string `json:"InstalledVersion"`
+			FixedVersion     string `json:"FixedVersion"`
+			Severity         string `json:"Severity"`
+		} `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+// parseTrivy Trivy JSON 리포트 파싱 (RepoDigests는 "<name>@sha256:..." 형식)
+func parseTrivy(data []byte) (*Report, error) {
+	var doc trivyReport
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode Trivy report: %v", err)
+	}
+
+	report := &Report{Format: FormatTrivy, Image: doc.ArtifactName}
+	for _, repoDigest := range doc.Metadata.RepoDigests {
+		report.addDigest(repoDigest)
+	}
+	report.addDigest(doc.Metadata.ImageID)
+	for _, result := range doc.Results {
+		for _, v := range result.Vulnerabilities {
+			report.Vulnerabilities = append(report.Vulnerabilities, Vulnerability{
+				ID:               v.VulnerabilityID,
+				Severity:         normalizeSeverity(v.Severity),
+				Package:          v.PkgName,
+				InstalledVersion: v.InstalledVersion,
+				FixedVersion:     v.FixedVersion,
+			})
+		}
+	}
+	return report, nil
+}
+
+// grypeReport Grype JSON 리포트 중 필요한 필드
+type grypeReport struct {
+	Matches []struct {
+		Vulnerability struct {
+			ID       string `json:"id"`
+			Severity string `json:"severity"`
+			Fix      struct {
+				Versions []string `json:"versions"`
+			} `json:"fix"`
+		} `json:"vulnerability"`
+		Artifact struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"artifact"`
+	} `json:"matches"`
+	Source struct {
+		Type   string          `json:"type"`
+		Target json.RawMessage `json:"target"`
+	} `json:"source"`
+}
+
+// grypeImageTarget 이미지 스캔일 때의 source.target
+type grypeImageTarget struct {
+	UserInput      string   `json:"userInput"`
+	ImageID        string   `json:"imageID"`
+	ManifestDigest string   `json:"manifestDigest"`
+	RepoDigests    []string `json:"repoDigests"`
+	Tags           []string `json:"tags"`
+}
+
+// parseGrype Grype JSON 리포트 파싱
+func parseGrype(data []byte) (*Report, error) {
+	var doc grypeReport
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode Grype report: %v", err)
+	}
+
+	report := &Report{Format: FormatGrype}
+	var target grypeImageTarget
+	if json.Unmarshal(doc.Source.Target, &target) == nil {
+		report.Image = target.UserInput
+		if report.Image == "" && len(target.Tags) > 0 {
+			report.Image = target.Tags[0]
+		}
+		for _, repoDigest := range target.RepoDigests {
+			report.addDigest(repoDigest)
+		}
+		report.addDigest(target.ManifestDigest)
+		report.addDigest(target.ImageID)
+	}
+	for _, match := range doc.Matches {
+		vulnerability := Vulnerability{
+			ID:               match.Vulnerability.ID,
+			Severity:         normalizeSeverity(match.Vulnerability.Severity),
+			Package:          match.Artifact.Name,
+			InstalledVersion: match.Artifact.Version,
+		}
+		if len(match.Vulnerability.Fix.Versions) > 0 {
+			vulnerability.FixedVersion = strings.Join(match.Vulnerability.Fix.Versions, ", ")
+		}
+		report.Vulnerabilities = append(report.Vulnerabilities, vulnerability)
+	}
+	return report, nil
+}
+
+// cycloneDXComponent CycloneDX 컴포넌트
+type cycloneDXComponent struct {
+	BOMRef     string `json:"bom-ref"`
+	Type       string `json:"type"`
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	PURL       string `json:"purl"`
+	Properties []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"properties"`
+}
+
+// cycloneDXBOM CycloneDX JSON SBOM 중 필요한 필드
+type cycloneDXBOM struct {
+	Metadata struct {
+		Component *cycloneDXComponent `json:"component"`
+	} `json:"metadata"`
+	Components      []cycloneDXComponent `json:"components"`
+	Vulnerabilities []struct {
+		ID      string `json:"id"`
+		Ratings []struct {
+			Severity string `json:"severity"`
+		} `json:"ratings"`
+		Affects []struct {
+			Ref string `json:"ref"`
+		} `json:"affects"`
+	} `json:"vulnerabilities"`
+}
+
+// parseCycloneDX CycloneDX JSON SBOM 파싱 (vulnerabilities가 있으면 취약점도 포함)
+func parseCycloneDX(data []byte) (*Report, error) {
+	var doc cycloneDXBOM
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode CycloneDX SBOM: %v", err)
+	}
+
+	report := &Report{Format: FormatCycloneDX, Packages: len(doc.Components)}
+	if component := doc.Metadata.Component; component != nil {
+		report.Image, report.Digests = ociPackageURL(component.PURL)
+		for _, property := range component.Properties {
+			switch {
+			case strings.HasSuffix(property.Name, ":RepoDigest"):
+				report.addDigest(property.Value)
+			case strings.HasSuffix(property.Name, ":ImageID"):
+				report.addDigest(property.Value)
+			case strings.HasSuffix(property.Name, ":RepoTag") && report.Image == "":
+				report.Image = property.Value
+			}
+		}
+		if report.Image == "" && component.Type == "container" {
+			report.Image = component.Name
+			if strings.HasPrefix(component.Version, "sha256:") {
+				report.addDigest(component.Version)
+			} else if component.Version != "" && !strings.Contains(component.Name, ":") {
+				report.Image += ":" + component.Version
+			}
+		}
+	}
+
+	components := make(map[string]cycloneDXComponent, len(doc.Components))
+	for _, component := range doc.Components {
+		components[component.BOMRef] = component
+	}
+	for _, v := range doc.Vulnerabilities {
+		severity := SeverityUnknown
+		for _, rating := range v.Ratings {
+			if s := normalizeSeverity(rating.Severity); severityWeights[s] > severityWeights[severity] {
+				severity = s
+			}
+		}
+		// 영향받는 컴포넌트가 없어도 취약점 하나로 집계
+		refs := []string{""}
+		if len(v.Affects) > 0 {
+			refs = refs[:0]
+			for _, affected := range v.Affects {
+				refs = append(refs, affected.Ref)
+			}
+		}
+		for _, ref := range refs {
+			component := components[ref]
+			report.Vulnerabilities = append(report.Vulnerabilities, Vulnerability{
+				ID:               v.ID,
+				Severity:         severity,
+				Package:          component.Name,
+				InstalledVersion: component.Version,
+			})
+		}
+	}
+	return report, nil
+}
+
+// spdxDocument SPDX JSON SBOM 중 필요한 필드
+type spdxDocument struct {
+	Name              string   `json:"name"`
+	DocumentDescribes []string `json:"documentDescribes"`
+	Packages          []struct {
+		SPDXID                string `json:"SPDXID"`
+		Name                  string `json:"name"`
+		VersionInfo           string `json:"versionInfo"`
+		PrimaryPackagePurpose string `json:"primaryPackagePurpose"`
+		ExternalRefs          []struct {
+			ReferenceType    string `json:"referenceType"`
+			ReferenceLocator string `json:"referenceLocator"`
+		} `json:"externalRefs"`
+	} `json:"packages"`
+}
+
+// parseSPDX SPDX JSON SBOM 파싱 (SPDX는 취약점을 포함하지 않으므로 이미지와 패키지 수만 읽음)
+func parseSPDX(data []byte) (*Report, error) {
+	var doc spdxDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode SPDX SBOM: %v", err)
+	}
+
+	report := &Report{Format: FormatSPDX, Packages: len(doc.Packages)}
+	describes := make(map[string]bool)
+	for _, id := range doc.DocumentDescribes {
+		describes[id] = true
+	}
+	for _, pkg := range doc.Packages {
+		if !describes[pkg.SPDXID] && pkg.PrimaryPackagePurpose != "CONTAINER" {
+			continue
+		}
+		report.Packages--
+		for _, ref := range pkg.ExternalRefs {
+			if ref.ReferenceType != "purl" {
+				continue
+			}
+			if image, digests := ociPackageURL(ref.ReferenceLocator); image != "" || len(digests) > 0 {
+				report.Image = image
+				report.Digests = append(report.Digests, digests...)
+			}
+		}
+		if report.Image == "" {
+			report.Image = pkg.Name
+		}
+		if strings.HasPrefix(pkg.VersionInfo, "sha256:") {
+			report.addDigest(pkg.VersionInfo)
+		}
+		break
+	}
+	if report.Image == "" {
+		report.Image = doc.Name
+	}
+	return report, nil
+}
+
+// ociPackageURL "pkg:oci/<name>@<digest>?repository_url=...&tag=..." 형식의 purl에서 이미지 참조와 digest를 추출
+func ociPackageURL(purl string) (string, []string) {
+	rest, ok := strings.CutPrefix(purl, "pkg:oci/")
+	if !ok {
+		return "", nil
+	}
+	rest, query, _ := strings.Cut(rest, "?")
+	name, version, _ := strings.Cut(rest, "@")
+	values, _ := url.ParseQuery(query)
+
+	image := name
+	if repository := values.Get("repository_url"); repository != "" {
+		image = repository
+	}
+	if tag := values.Get("tag"); tag != "" {
+		image += ":" + tag
+	}
+	var digests []string
+	if digest, err := url.PathUnescape(version); err == nil && strings.HasPrefix(digest, "sha256:") {
+		digests = append(digests, digest)
+	}
+	return image, digests
+}
+
+// addDigest "<name>@sha256:..." 또는 "sha256:..." 형식의 digest를 중복 없이 추가
+func (r *Report) addDigest(value string) {
+	if i := strings.LastIndex(value, "@"); i != -1 {
+		value = value[i+1:]
+	}
+	if !strings.HasPrefix(value, "sha256:") {
+		return
+	}
+	for _, digest := range r.Digests {
+		if digest == value {
+			return
+		}
+	}
+	r.Digests = append(r.Digests, value)
+}
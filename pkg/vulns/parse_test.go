@@ -0,0 +1,128 @@
+package vulns
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadReport(t *testing.T) {
+	tests := []struct {
+		file string
+		want Report
+	}{
+		{
+			file: "trivy-nginx.json",
+			want: Report{
+				Format: FormatTrivy,
+				Image:  "nginx:1.25.3",
+				Digests: []string{
+					"sha256:4c0fdaa8b6341bfdeca5f18f7837462c80cff90527ee35ef185571e1c327beac",
+					"sha256:a8758716bb6aa4d90071160d27028fe4eaee7ce8166221a97d30440c8eac2be6",
+				},
+				Vulnerabilities: []Vulnerability{
+					{ID: "CVE-2023-52425", Severity: SeverityHigh, Package: "libexpat1", InstalledVersion: "2.5.0-1", FixedVersion: "2.5.0-1+deb12u1"},
+					{ID: "CVE-2011-3374", Severity: SeverityLow, Package: "apt", InstalledVersion: "2.6.1"},
+					{ID: "CVE-2023-45288", Severity: SeverityMedium, Package: "golang.org/x/net", InstalledVersion: "v0.17.0", FixedVersion: "0.23.0"},
+				},
+			},
+		},
+		{
+			file: "grype-redis.json",
+			want: Report{
+				Format: FormatGrype,
+				Image:  "redis:7.2.3",
+				Digests: []string{
+					"sha256:396b35b30c2e5b6d6b4d1f6ad5c6ea3f3b7a8e5a3b0c0c8c07f6e4c8a5a1d2e3",
+					"sha256:3f6f4ba5d5a2a44b4d3e4d86e1f42c5c6f3b1a3e9c1d3b6e9a3f4a7d2e1c0b9a",
+					"sha256:76506809a39f2a2fd7f9a1b3ed4ae9be4c7b2a36bb3c8d8f8b6f2d7d6a3a1e33",
+				},
+				Vulnerabilities: []Vulnerability{
+					{ID: "CVE-2023-5363", Severity: SeverityCritical, Package: "libssl3", InstalledVersion: "3.0.11-1~deb12u1", FixedVersion: "3.0.11-1~deb12u2"},
+					{ID: "CVE-2005-2541", Severity: SeverityUnknown, Package: "tar", InstalledVersion: "1.34+dfsg-1.2"},
+				},
+			},
+		},
+		{
+			// 디렉터리 스캔은 source.target이 문자열이므로 이미지 정보가 없음
+			file: "grype-directory.json",
+			want: Report{
+				Format: FormatGrype,
+				Vulnerabilities: []Vulnerability{
+					{ID: "GHSA-jq35-85cj-fj4p", Severity: SeverityMedium, Package: "github.com/docker/docker", InstalledVersion: "v24.0.6+incompatible", FixedVersion: "1.12.1, 1.11.3"},
+				},
+			},
+		},
+		{
+			file: "cyclonedx-alpine.json",
+			want: Report{
+				Format: FormatCycloneDX,
+				Image:  "index.docker.io/library/alpine:3.19.0",
+				Digests: []string{
+					"sha256:1e42bbe2508154c9126d48c2b8a75420c3544343bf86fd041fb7527e017a4b4a",
+					"sha256:f8c20f8bbcb684055b4fea470fdd169c86e87786940b3262335b12ec3adef418",
+				},
+				Vulnerabilities: []Vulnerability{
+					{ID: "CVE-2023-6129", Severity: SeverityHigh, Package: "libcrypto3", InstalledVersion: "3.1.4-r2"},
+					{ID: "CVE-2023-6129", Severity: SeverityHigh, Package: "libssl3", InstalledVersion: "3.1.4-r2"},
+				},
+				Packages: 3,
+			},
+		},
+		{
+			file: "spdx-busybox.json",
+			want: Report{
+				Format: FormatSPDX,
+				Image:  "busybox:1.36.1",
+				Digests: []string{
+					"sha256:6d9ac9237a84afe1516540f40a0fafdc86859b2141954b4d643af7066d598b74",
+					"sha256:3f57d9401f8d42f986df300f0c69192fc41da28ccc8d797829467780db3dd741",
+				},
+				Packages: 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			path := filepath.Join("testdata", tt.file)
+			got, err := LoadReport(path)
+			if err != nil {
+				t.Fatalf("LoadReport() error = %v", err)
+			}
+			tt.want.Path = path
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("LoadReport() =\n%+v\nwant\n%+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadReportErrors(t *testing.T) {
+	for _, file := range []string{"unrecognized.json", "missing.json"} {
+		t.Run(file, func(t *testing.T) {
+			if _, err := LoadReport(filepath.Join("testdata", file)); err == nil {
+				t.Errorf("LoadReport() error = nil, want error")
+			}
+		})
+	}
+	if _, err := ParseReport([]byte("not json")); err == nil {
+		t.Errorf("ParseReport() error = nil, want error for invalid JSON")
+	}
+}
+
+func TestNormalizeSeverity(t *testing.T) {
+	tests := map[string]string{
+		"Critical":   SeverityCritical,
+		"high":       SeverityHigh,
+		" MEDIUM ":   SeverityMedium,
+		"Low":        SeverityLow,
+		"Negligible": SeverityUnknown,
+		"":           SeverityUnknown,
+	}
+	for input, want := range tests {
+		if got := normalizeSeverity(input); got != want {
+			t.Errorf("normalizeSeverity(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package vulns
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+// PrintExposures 실행 중인 Pod 수와 풀 횟수로 가중한 취약 이미지 목록과 요약 출력
+func PrintExposures(exposures []ImageExposure, unmatched []*Report, missing []string, since int) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "\nVulnerable Images in Use (pulls from the last %d hours):\n", since)
+	fmt.Fprintln(w, "=======================================================================")
+	fmt.Fprintln(w, "No.\tImage\tCritical\tHigh\tMedium\tLow\tFixable\tPods\tWorkloads\tPulls\tRisk\tMatch\tReport")
+	fmt.Fprintln(w, "-----------------------------------------------------------------------")
+	for i, exposure := range exposures {
+		fmt.Fprintf(w, "%d\t%s\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%s\t%s\n", i+1, exposure.Image,
+			exposure.Counts[SeverityCritical], exposure.Counts[SeverityHigh], exposure.Counts[SeverityMedium],
+			exposure.Counts[SeverityLow], exposure.Fixable, exposure.Pods, len(exposure.Workloads), exposure.Pulls,
+			exposure.Risk(), exposure.Match, exposure.Report.Path)
+	}
+	fmt.Fprintln(w, "=======================================================================")
+	w.Flush()
+
+	// 요약 정보 출력
+	var critical, high, pods int
+	for _, exposure := range exposures {
+		if exposure.Counts[SeverityCritical] > 0 {
+			critical++
+		}
+		if exposure.Counts[SeverityHigh] > 0 {
+			high++
+		}
+		if exposure.Total() > 0 {
+			pods += exposure.Pods
+		}
+	}
+	fmt.Printf("\nSummary:\n")
+	fmt.Printf("- Images matched to reports: %d\n", len(exposures))
+	fmt.Printf("- Images with critical vulnerabilities: %d\n", critical)
+	fmt.Printf("- Images with high vulnerabilities: %d\n", high)
+	fmt.Printf("- Pods running vulnerable images: %d\n", pods)
+	fmt.Printf("- In-use images without a report: %d\n", len(missing))
+	for _, image := range missing {
+		fmt.Printf("  - %s\n", image)
+	}
+	if len(unmatched) > 0 {
+		fmt.Printf("- Reports not matching any running image: %d\n", len(unmatched))
+		for _, report := range unmatched {
+			image := report.Image
+			if image == "" {
+				image = "unknown image"
+			}
+			fmt.Printf("  - %s (%s, %s)\n", report.Path, report.Format, image)
+		}
+	}
+}
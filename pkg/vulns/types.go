@@ -0,0 +1,65 @@
+package vulns
+
+import "strings"
+
+// 스캐너 리포트 형식
+const (
+	FormatTrivy     = "trivy"
+	FormatGrype     = "grype"
+	FormatCycloneDX = "cyclonedx"
+	FormatSPDX      = "spdx"
+)
+
+// 심각도 (스캐너마다 대소문자가 다르므로 대문자로 정규화)
+const (
+	SeverityCritical = "CRITICAL"
+	SeverityHigh     = "HIGH"
+	SeverityMedium   = "MEDIUM"
+	SeverityLow      = "LOW"
+	SeverityUnknown  = "UNKNOWN"
+)
+
+// severityWeights 위험도 계산에 사용하는 심각도별 가중치
+var severityWeights = map[string]int{
+	SeverityCritical: 10,
+	SeverityHigh:     5,
+	SeverityMedium:   2,
+	SeverityLow:      1,
+}
+
+// Vulnerability 이미지 패키지 하나의 취약점
+type Vulnerability struct {
+	ID               string
+	Severity         string
+	Package          string
+	InstalledVersion string
+	FixedVersion     string
+}
+
+// Fixable 수정된 버전이 있는지 확인
+func (v Vulnerability) Fixable() bool {
+	return v.FixedVersion != ""
+}
+
+// Report 스캐너 리포트 또는 SBOM 하나에서 읽은 이미지 정보와 취약점
+type Report struct {
+	// Path 리포트 파일 경로
+	Path   string
+	Format string
+	// Image 리포트가 설명하는 이미지 참조 (태그 포함, 없으면 빈 문자열)
+	Image string
+	// Digests 이미지의 매니페스트 digest 또는 이미지 ID
+	Digests         []string
+	Vulnerabilities []Vulnerability
+	// Packages SBOM에 포함된 패키지 수
+	Packages int
+}
+
+// normalizeSeverity 스캐너별 심각도 표기를 정규화 (Negligible, None 등은 UNKNOWN)
+func normalizeSeverity(severity string) string {
+	severity = strings.ToUpper(strings.TrimSpace(severity))
+	if _, ok := severityWeights[severity]; ok {
+		return severity
+	}
+	return SeverityUnknown
+}